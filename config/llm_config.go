@@ -0,0 +1,234 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"remedymate-backend/domain/dto"
+)
+
+// LoadLLMConfig loads the LLM provider settings from environment variables.
+// LLM_PROVIDER selects gemini (default), openai or ollama. LLM_API_KEY, LLM_MODEL
+// and LLM_BASE_URL configure it; for gemini, GEMINI_API_KEY and GEMINI_MODEL are
+// still honoured. LLM_MAX_TOKENS, LLM_TEMPERATURE and LLM_TIMEOUT_SECONDS set the
+// defaults used when a call does not override them.
+func LoadLLMConfig() (dto.LLMConfig, error) {
+	cfg, err := loadLLMDefaults()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Provider = strings.ToLower(strings.TrimSpace(os.Getenv("LLM_PROVIDER")))
+	cfg.APIKey = os.Getenv("LLM_API_KEY")
+	cfg.Model = os.Getenv("LLM_MODEL")
+	cfg.BaseURL = os.Getenv("LLM_BASE_URL")
+	if cfg.Provider == "" {
+		cfg.Provider = "gemini"
+	}
+	if cfg.Provider == "gemini" {
+		if cfg.APIKey == "" {
+			cfg.APIKey = os.Getenv("GEMINI_API_KEY")
+		}
+		if cfg.Model == "" {
+			cfg.Model = os.Getenv("GEMINI_MODEL")
+		}
+	}
+	cfg.Name = cfg.Provider + "/" + cfg.Model
+
+	if cfg.Model == "" {
+		return cfg, fmt.Errorf("LLM_MODEL is not set for provider %s", cfg.Provider)
+	}
+
+	return cfg, nil
+}
+
+// LoadLLMProviderConfigs loads the ordered provider fallback chain. LLM_PROVIDERS lists
+// provider:model entries, most preferred first, e.g.
+// "gemini:gemini-2.0-flash,gemini:gemini-2.0-flash-lite,ollama:llama3.1". Each provider
+// reads its API key and endpoint from <PROVIDER>_API_KEY and <PROVIDER>_BASE_URL
+// (GEMINI_API_KEY, OPENAI_BASE_URL, ...). Without LLM_PROVIDERS the single provider of
+// LoadLLMConfig is used. Missing credentials are not an error here: the router reports
+// such providers as unavailable so the service still starts.
+func LoadLLMProviderConfigs() ([]dto.LLMConfig, error) {
+	list := strings.TrimSpace(os.Getenv("LLM_PROVIDERS"))
+	if list == "" {
+		if _, err := loadLLMDefaults(); err != nil {
+			return nil, err
+		}
+		// The only other error is an unset model, which leaves the provider unavailable
+		cfg, _ := LoadLLMConfig()
+		return []dto.LLMConfig{cfg}, nil
+	}
+
+	defaults, err := loadLLMDefaults()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var configs []dto.LLMConfig
+	for _, entry := range strings.Split(list, ",") {
+		provider, model, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		model = strings.TrimSpace(model)
+		if !ok || provider == "" || model == "" {
+			return nil, fmt.Errorf("invalid LLM_PROVIDERS entry %q (want provider:model)", entry)
+		}
+		cfg := defaults
+		cfg.Name = provider + "/" + model
+		cfg.Provider = provider
+		cfg.Model = model
+		cfg.APIKey = os.Getenv(strings.ToUpper(provider) + "_API_KEY")
+		cfg.BaseURL = os.Getenv(strings.ToUpper(provider) + "_BASE_URL")
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("LLM_PROVIDERS lists %s twice", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// LoadLLMRouterConfig loads the failover settings. LLM_FAILOVER_TIMEOUT_SECONDS (default 20,
+// 0 disables it) is how long one provider may take, retries included, before the next is tried.
+func LoadLLMRouterConfig() (dto.LLMRouterConfig, error) {
+	cfg := dto.LLMRouterConfig{AttemptTimeout: 20 * time.Second}
+	if v := strings.TrimSpace(os.Getenv("LLM_FAILOVER_TIMEOUT_SECONDS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid LLM_FAILOVER_TIMEOUT_SECONDS %q", v)
+		}
+		cfg.AttemptTimeout = time.Duration(n) * time.Second
+	}
+	return cfg, nil
+}
+
+// loadLLMDefaults reads the generation settings shared by every provider
+func loadLLMDefaults() (dto.LLMConfig, error) {
+	cfg := dto.LLMConfig{
+		MaxTokens:   256,
+		Temperature: 0.1,
+		Timeout:     30,
+	}
+	if v := strings.TrimSpace(os.Getenv("LLM_MAX_TOKENS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid LLM_MAX_TOKENS %q", v)
+		}
+		cfg.MaxTokens = n
+	}
+	if v := strings.TrimSpace(os.Getenv("LLM_TEMPERATURE")); v != "" {
+		temps, err := ParseTemperatures(v)
+		if err != nil || len(temps) != 1 {
+			return cfg, fmt.Errorf("invalid LLM_TEMPERATURE %q", v)
+		}
+		cfg.Temperature = temps[0]
+	}
+	if v := strings.TrimSpace(os.Getenv("LLM_TIMEOUT_SECONDS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid LLM_TIMEOUT_SECONDS %q", v)
+		}
+		cfg.Timeout = n
+	}
+	return cfg, nil
+}
+
+// LoadLLMResilienceConfig loads the retry, concurrency and circuit breaker settings
+// wrapped around the LLM provider. LLM_MAX_RETRIES, LLM_RETRY_BASE_MS, LLM_RETRY_MAX_MS,
+// LLM_MAX_CONCURRENT, LLM_BREAKER_THRESHOLD and LLM_BREAKER_COOLDOWN_SECONDS override
+// the defaults; 0 disables retries, the concurrency cap or the breaker respectively.
+func LoadLLMResilienceConfig() (dto.LLMResilienceConfig, error) {
+	values := map[string]int{
+		"LLM_MAX_RETRIES":              2,
+		"LLM_RETRY_BASE_MS":            500,
+		"LLM_RETRY_MAX_MS":             8000,
+		"LLM_MAX_CONCURRENT":           8,
+		"LLM_BREAKER_THRESHOLD":        5,
+		"LLM_BREAKER_COOLDOWN_SECONDS": 30,
+	}
+	for name := range values {
+		v := strings.TrimSpace(os.Getenv(name))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return dto.LLMResilienceConfig{}, fmt.Errorf("invalid %s %q", name, v)
+		}
+		values[name] = n
+	}
+
+	return dto.LLMResilienceConfig{
+		MaxRetries:       values["LLM_MAX_RETRIES"],
+		BaseDelay:        time.Duration(values["LLM_RETRY_BASE_MS"]) * time.Millisecond,
+		MaxDelay:         time.Duration(values["LLM_RETRY_MAX_MS"]) * time.Millisecond,
+		MaxConcurrent:    values["LLM_MAX_CONCURRENT"],
+		FailureThreshold: values["LLM_BREAKER_THRESHOLD"],
+		OpenDuration:     time.Duration(values["LLM_BREAKER_COOLDOWN_SECONDS"]) * time.Second,
+	}, nil
+}
+
+// LoadLLMCacheConfig loads the response cache settings. LLM_CACHE_BACKEND is memory
+// (default), mongo or off; LLM_CACHE_TTL_SECONDS (default 86400) and LLM_CACHE_MAX_ENTRIES
+// (default 10000, 0 is unlimited) bound it.
+func LoadLLMCacheConfig() (dto.LLMCacheConfig, error) {
+	cfg := dto.LLMCacheConfig{
+		Backend:    strings.ToLower(strings.TrimSpace(os.Getenv("LLM_CACHE_BACKEND"))),
+		TTL:        24 * time.Hour,
+		MaxEntries: 10000,
+	}
+	switch cfg.Backend {
+	case "":
+		cfg.Backend = "memory"
+	case "memory", "mongo", "off":
+	default:
+		return cfg, fmt.Errorf("invalid LLM_CACHE_BACKEND %q (memory, mongo or off)", cfg.Backend)
+	}
+
+	if v := strings.TrimSpace(os.Getenv("LLM_CACHE_TTL_SECONDS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("invalid LLM_CACHE_TTL_SECONDS %q", v)
+		}
+		cfg.TTL = time.Duration(n) * time.Second
+	}
+	if v := strings.TrimSpace(os.Getenv("LLM_CACHE_MAX_ENTRIES")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid LLM_CACHE_MAX_ENTRIES %q", v)
+		}
+		cfg.MaxEntries = n
+	}
+	return cfg, nil
+}
+
+// LoadLLMBudgetConfig loads the daily token budget (LLM_DAILY_TOKEN_BUDGET, 0 or unset is
+// unlimited) and the prices per 1000 tokens used for cost estimates
+// (LLM_PROMPT_PRICE_PER_1K, LLM_COMPLETION_PRICE_PER_1K).
+func LoadLLMBudgetConfig() (dto.LLMBudgetConfig, error) {
+	var cfg dto.LLMBudgetConfig
+	if v := strings.TrimSpace(os.Getenv("LLM_DAILY_TOKEN_BUDGET")); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("invalid LLM_DAILY_TOKEN_BUDGET %q", v)
+		}
+		cfg.DailyTokens = n
+	}
+	for name, price := range map[string]*float64{
+		"LLM_PROMPT_PRICE_PER_1K":     &cfg.PromptPricePer1K,
+		"LLM_COMPLETION_PRICE_PER_1K": &cfg.CompletionPricePer1K,
+	} {
+		v := strings.TrimSpace(os.Getenv(name))
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return cfg, fmt.Errorf("invalid %s %q", name, v)
+		}
+		*price = f
+	}
+	return cfg, nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"remedymate-backend/domain/dto"
+)
+
+// LoadTopicMapperConfig loads the topic mapping settings. TOPIC_MAPPER is hybrid (default),
+// llm or local. TOPIC_MAPPER_MIN_SCORE (default 2.5) and TOPIC_MAPPER_MIN_MARGIN (default
+// 0.3) decide when the local match is confident enough to skip the LLM.
+func LoadTopicMapperConfig() (dto.TopicMapperConfig, error) {
+	cfg := dto.TopicMapperConfig{
+		Mode:      strings.ToLower(strings.TrimSpace(os.Getenv("TOPIC_MAPPER"))),
+		MinScore:  2.5,
+		MinMargin: 0.3,
+	}
+	switch cfg.Mode {
+	case "":
+		cfg.Mode = dto.TopicMapperHybrid
+	case dto.TopicMapperHybrid, dto.TopicMapperLLM, dto.TopicMapperLocal:
+	default:
+		return cfg, fmt.Errorf("invalid TOPIC_MAPPER %q (hybrid, llm or local)", cfg.Mode)
+	}
+
+	if v := strings.TrimSpace(os.Getenv("TOPIC_MAPPER_MIN_SCORE")); v != "" {
+		score, err := strconv.ParseFloat(v, 64)
+		if err != nil || score < 0 {
+			return cfg, fmt.Errorf("invalid TOPIC_MAPPER_MIN_SCORE %q", v)
+		}
+		cfg.MinScore = score
+	}
+	if v := strings.TrimSpace(os.Getenv("TOPIC_MAPPER_MIN_MARGIN")); v != "" {
+		margin, err := strconv.ParseFloat(v, 64)
+		if err != nil || margin < 0 || margin > 1 {
+			return cfg, fmt.Errorf("invalid TOPIC_MAPPER_MIN_MARGIN %q", v)
+		}
+		cfg.MinMargin = margin
+	}
+	return cfg, nil
+}
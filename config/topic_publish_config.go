@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/util/lang"
+)
+
+// LoadTopicPublishConfig loads the checks run before a topic is published.
+// TOPIC_REQUIRED_LANGUAGES is a comma separated list of enabled languages, e.g. "en,am", whose
+// translations must be complete; unset, topics are published without the check. Load the
+// language registry first.
+func LoadTopicPublishConfig() (dto.TopicPublishConfig, error) {
+	var cfg dto.TopicPublishConfig
+	for _, code := range strings.Split(os.Getenv("TOPIC_REQUIRED_LANGUAGES"), ",") {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if !lang.IsSupported(code) {
+			return cfg, fmt.Errorf("invalid TOPIC_REQUIRED_LANGUAGES: %q is not an enabled language", code)
+		}
+		cfg.RequiredLanguages = append(cfg.RequiredLanguages, code)
+	}
+	return cfg, nil
+}
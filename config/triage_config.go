@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"remedymate-backend/domain/dto"
+)
+
+// LoadTriageConfig loads triage settings from environment variables.
+// TRIAGE_CONSENSUS_SAMPLES sets how many LLM votes each triage takes (default 1) and
+// TRIAGE_CONSENSUS_TEMPERATURES is an optional comma separated list used round-robin.
+func LoadTriageConfig() (dto.TriageConfig, error) {
+	cfg := dto.TriageConfig{ConsensusSamples: 1}
+
+	if v := strings.TrimSpace(os.Getenv("TRIAGE_CONSENSUS_SAMPLES")); v != "" {
+		samples, err := strconv.Atoi(v)
+		if err != nil || samples < 1 {
+			return cfg, fmt.Errorf("invalid TRIAGE_CONSENSUS_SAMPLES %q", v)
+		}
+		cfg.ConsensusSamples = samples
+	}
+
+	temps, err := ParseTemperatures(os.Getenv("TRIAGE_CONSENSUS_TEMPERATURES"))
+	if err != nil {
+		return cfg, fmt.Errorf("invalid TRIAGE_CONSENSUS_TEMPERATURES: %w", err)
+	}
+	cfg.ConsensusTemperatures = temps
+
+	return cfg, nil
+}
+
+// ParseTemperatures parses a comma separated list such as "0.1,0.5,0.9"
+func ParseTemperatures(list string) ([]float32, error) {
+	var temps []float32
+	for _, t := range strings.Split(list, ",") {
+		if t = strings.TrimSpace(t); t == "" {
+			continue
+		}
+		temp, err := strconv.ParseFloat(t, 32)
+		if err != nil || temp < 0 || temp > 2 {
+			return nil, fmt.Errorf("%q is not a temperature between 0 and 2", t)
+		}
+		temps = append(temps, float32(temp))
+	}
+	return temps, nil
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"net/http"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminLLMController struct {
+	uc interfaces.AdminLLMUsecase
+}
+
+func NewAdminLLMController(uc interfaces.AdminLLMUsecase) *AdminLLMController {
+	return &AdminLLMController{uc: uc}
+}
+
+// CacheStats returns response cache hits and misses per scope since startup
+func (c *AdminLLMController) CacheStats(ctx *gin.Context) {
+	stats, err := c.uc.CacheStats(ctx.Request.Context())
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// Usage returns daily token usage per feature and model with today's budget status.
+// Accepts feature, and from/to as RFC3339 timestamps or plain dates (a plain "to" date is inclusive).
+func (c *AdminLLMController) Usage(ctx *gin.Context) {
+	filter := dto.LLMUsageFilter{Feature: ctx.Query("feature")}
+	if from := ctx.Query("from"); from != "" {
+		t, _, err := parseAuditTime(from)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date"})
+			return
+		}
+		filter.From = &t
+	}
+	if to := ctx.Query("to"); to != "" {
+		t, dateOnly, err := parseAuditTime(to)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date"})
+			return
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.To = &t
+	}
+
+	report, err := c.uc.Usage(ctx.Request.Context(), filter)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Providers lists the configured LLM providers in fallback order with their health
+func (c *AdminLLMController) Providers(ctx *gin.Context) {
+	providers, err := c.uc.Providers(ctx.Request.Context())
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"items": providers})
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminPromptController struct {
+	uc interfaces.AdminPromptUsecase
+}
+
+func NewAdminPromptController(uc interfaces.AdminPromptUsecase) *AdminPromptController {
+	return &AdminPromptController{uc: uc}
+}
+
+// List returns every prompt with its template fields and the version in use
+func (c *AdminPromptController) List(ctx *gin.Context) {
+	items, err := c.uc.List(ctx.Request.Context())
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// Versions returns all versions of a prompt, newest first
+func (c *AdminPromptController) Versions(ctx *gin.Context) {
+	items, err := c.uc.Versions(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (c *AdminPromptController) Get(ctx *gin.Context) {
+	version, ok := promptVersionParam(ctx)
+	if !ok {
+		return
+	}
+	item, err := c.uc.Get(ctx.Request.Context(), ctx.Param("name"), version)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, item)
+}
+
+// Create adds the next version of a prompt; it is validated against the prompt's fields first
+func (c *AdminPromptController) Create(ctx *gin.Context) {
+	var in dto.CreatePromptVersionDTO
+	if err := ctx.ShouldBindJSON(&in); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+		return
+	}
+	actor := ctx.GetString("userID")
+	item, err := c.uc.Create(ctx.Request.Context(), ctx.Param("name"), in, actor)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, item)
+}
+
+// Activate makes a version live; activating an older version rolls back
+func (c *AdminPromptController) Activate(ctx *gin.Context) {
+	version, ok := promptVersionParam(ctx)
+	if !ok {
+		return
+	}
+	actor := ctx.GetString("userID")
+	item, err := c.uc.Activate(ctx.Request.Context(), ctx.Param("name"), version, actor)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, item)
+}
+
+// promptVersionParam reads the :version path parameter; it writes a 400 and returns false when it is not a number
+func promptVersionParam(ctx *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminTriageAuditController struct {
+	uc interfaces.AdminTriageAuditUsecase
+}
+
+func NewAdminTriageAuditController(uc interfaces.AdminTriageAuditUsecase) *AdminTriageAuditController {
+	return &AdminTriageAuditController{uc: uc}
+}
+
+// List searches triage audit records by level, language and date range.
+// from/to accept RFC3339 timestamps or plain dates (YYYY-MM-DD); a plain "to" date is inclusive.
+func (c *AdminTriageAuditController) List(ctx *gin.Context) {
+	filter, ok := auditFilterFromQuery(ctx)
+	if !ok {
+		return
+	}
+
+	items, total, err := c.uc.Search(ctx.Request.Context(), filter)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"items": items, "total": total})
+}
+
+func (c *AdminTriageAuditController) Get(ctx *gin.Context) {
+	id := ctx.Param("id")
+	item, err := c.uc.Get(ctx.Request.Context(), id)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, item)
+}
+
+// GetRuleSnapshot returns the red/yellow rules that were in force for a rules version
+func (c *AdminTriageAuditController) GetRuleSnapshot(ctx *gin.Context) {
+	version := ctx.Param("version")
+	item, err := c.uc.GetRuleSnapshot(ctx.Request.Context(), version)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, item)
+}
+
+// RuleStats reports how often each red/yellow flag rule fired, using the same filters as List
+func (c *AdminTriageAuditController) RuleStats(ctx *gin.Context) {
+	filter, ok := auditFilterFromQuery(ctx)
+	if !ok {
+		return
+	}
+
+	items, err := c.uc.RuleStats(ctx.Request.Context(), filter)
+	if err != nil {
+		HandleHTTPError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"items": items, "total": len(items)})
+}
+
+// auditFilterFromQuery reads level, language, from, to, limit and offset; it writes a 400 and returns false on bad dates
+func auditFilterFromQuery(ctx *gin.Context) (dto.TriageAuditFilter, bool) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	filter := dto.TriageAuditFilter{
+		Level:    strings.ToUpper(ctx.Query("level")),
+		Language: ctx.Query("language"),
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		t, _, err := parseAuditTime(from)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date"})
+			return filter, false
+		}
+		filter.From = &t
+	}
+	if to := ctx.Query("to"); to != "" {
+		t, dateOnly, err := parseAuditTime(to)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date"})
+			return filter, false
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.To = &t
+	}
+	return filter, true
+}
+
+// parseAuditTime parses RFC3339 or YYYY-MM-DD and reports whether only a date was given
+func parseAuditTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	return t, true, err
+}
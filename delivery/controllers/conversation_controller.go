@@ -3,24 +3,30 @@ package controllers
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	derrors "remedymate-backend/domain/AppError"
 	"remedymate-backend/domain/dto"
 	"remedymate-backend/domain/entities"
 	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/util/lang"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ConversationController struct {
 	conversationUsecase interfaces.ConversationUsecase
+	messages            interfaces.MessageCatalog
 }
 
-func NewConversationController(conversationUsecase interfaces.ConversationUsecase) *ConversationController {
+func NewConversationController(conversationUsecase interfaces.ConversationUsecase, messages interfaces.MessageCatalog) *ConversationController {
 	return &ConversationController{
 		conversationUsecase: conversationUsecase,
+		messages:            messages,
 	}
 }
 
@@ -40,7 +46,7 @@ func (cc *ConversationController) GetOfflineHealthTopics(c *gin.Context) {
 // POST /api/v1/conversation/init
 func (cc *ConversationController) InitiateChat(c *gin.Context) {
 	var req struct {
-		Language string `json:"language" binding:"required" validate:"oneof=en am"`
+		Language string `json:"language" binding:"required,language"`
 		UserID   string `json:"user_id,omitempty"`
 	}
 
@@ -53,16 +59,9 @@ func (cc *ConversationController) InitiateChat(c *gin.Context) {
 	}
 
 	// Create a welcoming response based on language
-	var heading, subheading, message string
-	if req.Language == "am" {
-		heading = "ሰላም! እንዴት ሊረዳዎ እችላለሁ?"
-		subheading = "የጤና ሁኔታዎን ይንገሩኝ"
-		message = "የሚሰማዎትን ምልክት ወይም ችግር ይጥቀሱ፣ እና ተጨማሪ ጥያቄዎችን ጠይቄ ሊረዳዎ እሞክራለሁ።"
-	} else {
-		heading = "Hello! How can I help you today?"
-		subheading = "Tell me about your health concern"
-		message = "Please describe your symptom or health issue, and I'll ask follow-up questions to better understand your condition."
-	}
+	heading := cc.messages.Message(req.Language, "conversation.greeting.heading", nil)
+	subheading := cc.messages.Message(req.Language, "conversation.greeting.subheading", nil)
+	message := cc.messages.Message(req.Language, "conversation.greeting.message", nil)
 
 	response := dto.ConversationResponse{
 		ConversationID:    "", // No conversation ID yet
@@ -96,16 +95,18 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 		// Starting a new conversation - symptom and language are required
 		if req.Symptom == "" {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error:   "Symptom required",
-				Details: "Please describe your symptom or health concern to start the conversation. Use the /init endpoint for initial greeting.",
+				Error:   cc.messages.Message(req.Language, "conversation.symptom.required", nil),
+				Details: cc.messages.Message(req.Language, "conversation.symptom.required_details", nil),
 			})
 			return
 		}
 
 		if req.Language == "" {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error:   "Language required",
-				Details: "Please specify your preferred language (en or am).",
+				Error: cc.messages.Message(req.Language, "conversation.language.required", nil),
+				Details: cc.messages.Message(req.Language, "conversation.language.required_details", map[string]interface{}{
+					"languages": strings.Join(lang.Codes(), ", "),
+				}),
 			})
 			return
 		}
@@ -113,6 +114,10 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 		// Validate symptom using LLM
 		isValid, feedback, err := cc.conversationUsecase.ValidateSymptom(c.Request.Context(), req.Symptom, req.Language)
 		if err != nil {
+			if errors.Is(err, derrors.ErrLLMDegraded) {
+				c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Failed to validate symptom", Details: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Error:   "Failed to validate symptom",
 				Details: err.Error(),
@@ -122,7 +127,7 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 
 		if !isValid {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error:   "Please describe your health concern",
+				Error:   cc.messages.Message(req.Language, "conversation.symptom.invalid", nil),
 				Details: feedback,
 			})
 			return
@@ -132,10 +137,15 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 			Symptom:  req.Symptom,
 			Language: req.Language,
 			UserID:   req.UserID,
+			Patient:  req.Patient,
 		}
 
 		response, err := cc.conversationUsecase.StartConversation(c.Request.Context(), startReq)
 		if err != nil {
+			if errors.Is(err, derrors.ErrLLMDegraded) {
+				c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Failed to start conversation", Details: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Error:   "Failed to start conversation",
 				Details: err.Error(),
@@ -146,8 +156,8 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 		// Convert to unified response format
 		unifiedResponse := dto.ConversationResponse{
 			ConversationID:    response.ConversationID,
-			Heading:           "Let's assess your symptoms",
-			Subheading:        "I'll ask you a few questions to understand your condition better",
+			Heading:           cc.messages.Message(req.Language, "conversation.start.heading", nil),
+			Subheading:        cc.messages.Plural(req.Language, "conversation.start.subheading", response.TotalSteps, nil),
 			Question:          &response.Question,
 			IsComplete:        false,
 			CurrentStep:       response.CurrentStep,
@@ -160,8 +170,8 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 		// Continuing an existing conversation
 		if req.Answer == "" {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-				Error:   "Missing answer",
-				Details: "answer is required for continuing a conversation",
+				Error:   cc.messages.Message(req.Language, "conversation.answer.required", nil),
+				Details: cc.messages.Message(req.Language, "conversation.answer.required_details", nil),
 			})
 			return
 		}
@@ -190,6 +200,11 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 				return
 			}
 
+			if errors.Is(err, derrors.ErrLLMDegraded) {
+				c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Failed to submit answer", Details: err.Error()})
+				return
+			}
+
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Error:   "Failed to submit answer",
 				Details: err.Error(),
@@ -198,10 +213,14 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 		}
 
 		// Convert to unified response format
+		language := response.Language
 		unifiedResponse := dto.ConversationResponse{
-			ConversationID:    response.ConversationID,
-			Heading:           fmt.Sprintf("Question %d of %d", response.CurrentStep, response.TotalSteps),
-			Subheading:        "Please provide more details",
+			ConversationID: response.ConversationID,
+			Heading: cc.messages.Message(language, "conversation.progress.heading", map[string]interface{}{
+				"current": response.CurrentStep,
+				"total":   response.TotalSteps,
+			}),
+			Subheading:        cc.messages.Message(language, "conversation.progress.subheading", nil),
 			Question:          response.Question,
 			Message:           response.Message,
 			IsComplete:        response.IsComplete,
@@ -220,20 +239,7 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 			if err != nil {
 				// If report generation failed, create a basic remedy
 				fmt.Printf("Error getting report: %v\n", err)
-				remedy = &dto.RemedyResponse{
-					SessionID: generateSessionID(),
-					Triage: dto.TriageResponse{
-						Level:    "YELLOW",
-						RedFlags: []string{},
-						Message:  "Please consult a healthcare provider for personalized advice",
-					},
-					Content: &entities.GuidanceCard{
-						SelfCare:      []string{"Rest", "Stay hydrated", "Monitor your symptoms"},
-						OTCCategories: []entities.OTCCategory{},
-						SeekCareIf:    []string{"Symptoms worsen", "New symptoms appear"},
-						Disclaimer:    "This is general advice. Please consult a healthcare provider for personalized care.",
-					},
-				}
+				remedy = cc.basicRemedy(language, entities.TriageLevelYellow)
 			} else if reportResponse.Report != nil && reportResponse.Report.Remedy != nil {
 				// Use remedy from report
 				remedy = &dto.RemedyResponse{
@@ -255,20 +261,7 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 			} else {
 				// No remedy in report, create a basic one
 				fmt.Printf("No remedy found in report, creating basic remedy\n")
-				remedy = &dto.RemedyResponse{
-					SessionID: generateSessionID(),
-					Triage: dto.TriageResponse{
-						Level:    "RED",
-						RedFlags: []string{},
-						Message:  "Please consult a healthcare provider for personalized advice",
-					},
-					Content: &entities.GuidanceCard{
-						SelfCare:      []string{"Rest", "Stay hydrated", "Monitor your symptoms"},
-						OTCCategories: []entities.OTCCategory{},
-						SeekCareIf:    []string{"Symptoms worsen", "New symptoms appear"},
-						Disclaimer:    "This is general advice. Please consult a healthcare provider for personalized care.",
-					},
-				}
+				remedy = cc.basicRemedy(language, entities.TriageLevelRed)
 			}
 
 			// Set the remedy in response
@@ -276,12 +269,13 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 
 			// Set heading based on triage level
 			if remedy.Triage.Level == "RED" {
-				unifiedResponse.Heading = "⚠️ Medical Emergency Detected"
-				unifiedResponse.Subheading = "Your symptoms require immediate medical attention"
-				unifiedResponse.Message = "Please seek immediate medical care. Do not delay."
+				unifiedResponse.Heading = cc.messages.Message(language, "remedy.emergency.heading", nil)
+				unifiedResponse.Subheading = cc.messages.Message(language, "remedy.emergency.subheading", nil)
+				unifiedResponse.Message = cc.messages.Message(language, "remedy.emergency.message", nil)
 			} else {
-				unifiedResponse.Heading = "Your Personalized Remedy"
-				unifiedResponse.Subheading = "Based on your symptoms, here's what we recommend"
+				unifiedResponse.Heading = cc.messages.Message(language, "remedy.heading", nil)
+				unifiedResponse.Subheading = cc.messages.Message(language, "remedy.subheading", nil)
+				unifiedResponse.Message = cc.messages.Message(language, "conversation.complete.message", nil)
 			}
 
 			c.JSON(http.StatusOK, unifiedResponse)
@@ -291,6 +285,26 @@ func (cc *ConversationController) HandleConversation(c *gin.Context) {
 	}
 }
 
+// basicRemedy is the generic advice shown when no remedy could be generated
+func (cc *ConversationController) basicRemedy(language string, level entities.TriageLevel) *dto.RemedyResponse {
+	msg := func(key string) string { return cc.messages.Message(language, key, nil) }
+	return &dto.RemedyResponse{
+		SessionID: generateSessionID(),
+		Triage: dto.TriageResponse{
+			Level:    level,
+			RedFlags: []string{},
+			Message:  msg("remedy.fallback.message"),
+		},
+		Content: &entities.GuidanceCard{
+			Language:      language,
+			SelfCare:      []string{msg("remedy.fallback.self_care.rest"), msg("remedy.fallback.self_care.hydrate"), msg("remedy.fallback.self_care.monitor")},
+			OTCCategories: []entities.OTCCategory{},
+			SeekCareIf:    []string{msg("remedy.fallback.seek_care.worse"), msg("remedy.fallback.seek_care.new")},
+			Disclaimer:    msg("remedy.fallback.disclaimer"),
+		},
+	}
+}
+
 // generateSessionID generates a unique session ID
 func generateSessionID() string {
 	b := make([]byte, 16)
@@ -30,6 +30,18 @@ func HandleHTTPError(c *gin.Context, err error) {
 	case errors.Is(err, AppError.ErrNoTopicMapped):
 		c.JSON(404, gin.H{"error": err.Error()})
 
+	// triage audit
+	case errors.Is(err, AppError.ErrTriageAuditNotFound):
+		c.JSON(404, gin.H{"error": err.Error()})
+	case errors.Is(err, AppError.ErrRuleSnapshotNotFound):
+		c.JSON(404, gin.H{"error": err.Error()})
+
+	// prompt templates
+	case errors.Is(err, AppError.ErrPromptTemplateNotFound):
+		c.JSON(404, gin.H{"error": err.Error()})
+	case errors.Is(err, AppError.ErrInvalidPromptTemplate):
+		c.JSON(400, gin.H{"error": err.Error()})
+
 	// user-related
 	case errors.Is(err, AppError.ErrUserNotFound):
 		c.JSON(404, gin.H{"error": err.Error()})
@@ -54,6 +66,10 @@ func HandleHTTPError(c *gin.Context, err error) {
 	case errors.Is(err, AppError.ErrRefreshTokenNotFound):
 		c.JSON(404, gin.H{"error": err.Error()})
 
+	// LLM provider unavailable or circuit open
+	case errors.Is(err, AppError.ErrLLMDegraded):
+		c.JSON(503, gin.H{"error": err.Error()})
+
 	// server errors
 	case errors.Is(err, AppError.ErrInternalServer):
 		c.JSON(500, gin.H{"error": err.Error()})
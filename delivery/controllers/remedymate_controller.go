@@ -44,6 +44,10 @@ func (rmc *RemedyMateController) GetRemedy(c *gin.Context) {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Topic not found", Details: err.Error()})
 			return
 		}
+		if errors.Is(err, derrors.ErrLLMDegraded) {
+			c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Failed to get remedy", Details: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to get remedy", Details: err.Error()})
 		return
 	}
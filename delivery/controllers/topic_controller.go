@@ -1,22 +1,33 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"remedymate-backend/domain/AppError"
 	"remedymate-backend/domain/dto"
 	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/util/topicfile"
 
 	"github.com/gin-gonic/gin"
 )
 
 const defaultControllerTimeout = 5 * time.Second
 
+// Imports, exports and reports go through every topic, so they get longer than other requests
+const topicBulkTimeout = 60 * time.Second
+
+// maxTopicImportBytes caps the size of an uploaded topic file
+const maxTopicImportBytes = 10 << 20
+
 type TopicController struct {
 	topicUsecase interfaces.TopicUsecase
 }
@@ -78,6 +89,41 @@ func (tc *TopicController) UpdateTopicHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, updatedTopic)
 }
 
+// TransitionTopicHandler moves a topic to another editorial state
+func (tc *TopicController) TransitionTopicHandler(c *gin.Context) {
+	topicKey := c.Param("topic_key")
+	var req dto.TopicTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
+	defer cancel()
+
+	topic, err := tc.topicUsecase.TransitionTopic(ctx, topicKey, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, AppError.ErrTopicNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrInvalidTopicTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrSelfReview):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrIncompleteTranslation):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrUserNotAuthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, topic)
+}
+
 // GetTopicHandler retrieves a topic by key
 func (tc *TopicController) GetTopicHandler(c *gin.Context) {
 	topicKey := c.Param("topic_key")
@@ -112,6 +158,10 @@ func (tc *TopicController) DeleteTopicHandler(c *gin.Context) {
 		switch {
 		case errors.Is(err, AppError.ErrTopicNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrUserNotAuthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -127,11 +177,13 @@ func (tc *TopicController) ListAllTopicsHandler(c *gin.Context) {
 	search := c.DefaultQuery("search", "")
 	sortBy := c.DefaultQuery("sort_by", "name_en")
 	sortOrder := c.DefaultQuery("sort_order", "asc")
+	state := c.Query("state")
 
 	params := dto.TopicListQueryParams{
 		PaginationQueryParams: dto.PaginationQueryParams{Page: page, Limit: limit},
 		FilterQueryParams:     dto.FilterQueryParams{Search: search},
 		SortQueryParams:       dto.SortQueryParams{SortBy: sortBy, Order: sortOrder},
+		State:                 state,
 	}
 
 	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
@@ -147,3 +199,238 @@ func (tc *TopicController) ListAllTopicsHandler(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, res)
 }
+
+// ListRevisionsHandler returns every published version of a topic, oldest first
+func (tc *TopicController) ListRevisionsHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
+	defer cancel()
+
+	revisions, err := tc.topicUsecase.ListRevisions(ctx, c.Param("topic_key"))
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// GetRevisionHandler returns one published version of a topic
+func (tc *TopicController) GetRevisionHandler(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a number"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
+	defer cancel()
+
+	revision, err := tc.topicUsecase.GetRevision(ctx, c.Param("topic_key"), version)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffRevisionsHandler compares two versions of a topic; either may be "draft"
+func (tc *TopicController) DiffRevisionsHandler(c *gin.Context) {
+	from, to := c.Query("from"), c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to are required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
+	defer cancel()
+
+	diff, err := tc.topicUsecase.DiffRevisions(ctx, c.Param("topic_key"), from, to)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// RollbackTopicHandler submits an earlier version for review as a new draft
+func (tc *TopicController) RollbackTopicHandler(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a number"})
+		return
+	}
+	var req dto.TopicRollbackRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
+	defer cancel()
+
+	topic, err := tc.topicUsecase.RollbackTopic(ctx, c.Param("topic_key"), version, req.Notes)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, topic)
+}
+
+// ServedAtHandler reports which version of a topic users were given at a point in time
+func (tc *TopicController) ServedAtHandler(c *gin.Context) {
+	at, err := time.Parse(time.RFC3339, c.Query("at"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at must be an RFC 3339 time, e.g. 2025-03-01T12:00:00Z"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, defaultControllerTimeout)
+	defer cancel()
+
+	served, err := tc.topicUsecase.ServedAt(ctx, c.Param("topic_key"), at)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, served)
+}
+
+// ExportTopicsHandler downloads topics as JSON or CSV, optionally filtered by state, topic keys
+// and languages
+func (tc *TopicController) ExportTopicsHandler(c *gin.Context) {
+	format, err := topicfile.FormatOf(c.Query("format"), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query := dto.TopicExportQuery{
+		State:     c.Query("state"),
+		TopicKeys: splitList(c.Query("keys")),
+		Languages: splitList(c.Query("lang")),
+	}
+
+	ctx, cancel := context.WithTimeout(c, topicBulkTimeout)
+	defer cancel()
+
+	records, err := tc.topicUsecase.ExportTopics(ctx, query)
+	if err != nil {
+		if errors.Is(err, AppError.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("❌ Topic export failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	var body bytes.Buffer
+	if err := topicfile.Write(&body, format, records); err != nil {
+		log.Printf("❌ Topic export failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="topics-%s.%s"`, time.Now().Format("2006-01-02"), format))
+	c.Data(http.StatusOK, topicfile.ContentType(format), body.Bytes())
+}
+
+// ImportTopicsHandler upserts topics from a JSON or CSV file, uploaded as the "file" form field
+// or sent as the request body. With dry_run=true it only reports what would change.
+func (tc *TopicController) ImportTopicsHandler(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxTopicImportBytes)
+
+	var file io.Reader = c.Request.Body
+	fileName := ""
+	if header, err := c.FormFile("file"); err == nil {
+		upload, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload", "details": err.Error()})
+			return
+		}
+		defer upload.Close()
+		file, fileName = upload, header.Filename
+	} else if c.ContentType() == "text/csv" {
+		fileName = "topics.csv"
+	}
+	format, err := topicfile.FormatOf(c.Query("format"), fileName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	records, err := topicfile.Read(file, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, topicBulkTimeout)
+	defer cancel()
+
+	report, err := tc.topicUsecase.ImportTopics(ctx, records, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, AppError.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, AppError.ErrUserNotAuthenticated):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			log.Printf("❌ Topic import failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if report.Invalid > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// TranslationReportHandler lists the translation problems of every active topic, optionally
+// for the given languages only
+func (tc *TopicController) TranslationReportHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, topicBulkTimeout)
+	defer cancel()
+
+	report, err := tc.topicUsecase.TranslationReport(ctx, splitList(c.Query("lang")))
+	if err != nil {
+		if errors.Is(err, AppError.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("❌ Translation report failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// splitList splits a comma separated query value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// writeRevisionError maps the errors of the revision endpoints to a status
+func writeRevisionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, AppError.ErrTopicNotFound), errors.Is(err, AppError.ErrTopicRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, AppError.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, AppError.ErrInvalidTopicTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, AppError.ErrIncompleteTranslation):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, AppError.ErrUserNotAuthenticated):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		log.Printf("❌ Topic revision request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"remedymate-backend/config"
 	"remedymate-backend/delivery/controllers"
 	"remedymate-backend/delivery/routers"
 	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
 
 	"remedymate-backend/infrastructure/bootstrap"
 
@@ -15,12 +21,15 @@ import (
 	"remedymate-backend/infrastructure/conversation"
 	"remedymate-backend/infrastructure/database"
 	"remedymate-backend/infrastructure/guidance"
+	"remedymate-backend/infrastructure/i18n"
 	"remedymate-backend/infrastructure/llm"
 	mailInfra "remedymate-backend/infrastructure/mail"
+	"remedymate-backend/infrastructure/prompts"
 	"remedymate-backend/infrastructure/remedymate_services"
 	"remedymate-backend/repository"
 	"remedymate-backend/usecase"
 	"remedymate-backend/usecase/user"
+	"remedymate-backend/util/lang"
 
 	"github.com/joho/godotenv"
 )
@@ -31,6 +40,21 @@ func main() {
 		log.Println("Warning: .env file not found")
 	}
 
+	// Load the language registry; the built-in English/Amharic registry is used without a config file
+	languagesPath := os.Getenv("LANGUAGES_CONFIG")
+	if languagesPath == "" {
+		languagesPath = "config/languages.json"
+	}
+	if registry, err := lang.LoadRegistry(languagesPath); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Fatalf("❌ Invalid language configuration: %v", err)
+		}
+		log.Printf("⚠️ %s not found, using built-in languages %v", languagesPath, lang.Codes())
+	} else {
+		lang.SetDefault(registry)
+		log.Printf("✅ Languages enabled: %v", registry.Codes())
+	}
+
 	// Connect to MongoDB
 	database.ConnectMongo()
 
@@ -47,16 +71,39 @@ func main() {
 	conversationRepo := repository.NewConversationRepository(database.GetCollection("conversation"))
 	redFlagRepo := repository.NewRedFlagRepository()
 	feedbackRepo := repository.NewFeedbackRepository()
+	triageAuditRepo := repository.NewTriageAuditRepository()
+	llmUsageRepo := repository.NewLLMUsageRepository()
+	promptRepo := repository.NewPromptTemplateRepository()
 	topicRepo, err := repository.NewTopicRepository()
 	if err != nil {
 		log.Fatalf("Failed to initialize TopicRepository: %v", err)
 	}
+	topicRevisionRepo := repository.NewTopicRevisionRepository()
 
 	// Seed superadmin user
 	if err := bootstrap.SeedSuperAdmin(userRepo); err != nil {
 		log.Fatalf("Failed to seed superadmin: %v", err)
 	}
 
+	// Seed red flag rules from data/*.json that are not in the collection yet
+	if err := bootstrap.SeedRedFlags(redFlagRepo, "./data"); err != nil {
+		log.Printf("❌ Failed to seed red flag rules: %v", err)
+	}
+
+	// Seed topics from data/approved_block.json; topics admins created, edited or deleted are kept
+	if err := bootstrap.SeedTopics(topicRepo, topicRevisionRepo, "./data"); err != nil {
+		log.Printf("❌ Failed to seed topics: %v", err)
+	}
+
+	// Seed prompt template versions from data/prompts; versions created by admins are kept
+	promptsDir := os.Getenv("PROMPTS_DIR")
+	if promptsDir == "" {
+		promptsDir = "./data/prompts"
+	}
+	if err := bootstrap.SeedPromptTemplates(promptRepo, promptsDir); err != nil {
+		log.Printf("❌ Failed to seed prompt templates: %v", err)
+	}
+
 	// Initialize mail service
 	mailService := mailInfra.NewSMTPMailService()
 
@@ -65,56 +112,176 @@ func main() {
 	userUsecase := user.NewUserUsecase(userRepo)
 
 	publicFeedbackUsecase := usecase.NewPublicFeedbackUsecase(feedbackRepo)
-	topicUsecase := usecase.NewTopicUsecase(topicRepo)
 
 	// Initialize RemedyMate services
-	contentService := content.NewContentService("./data")
+	contentService := content.NewContentService("./data", redFlagRepo, topicRepo)
+
+	// Triage rules are reloaded periodically in addition to being refreshed on admin edits
+	rulesRefreshInterval := 60 * time.Second
+	if v, err := strconv.Atoi(os.Getenv("TRIAGE_RULES_REFRESH_SECONDS")); err == nil {
+		rulesRefreshInterval = time.Duration(v) * time.Second
+	}
+	triageRules := contentService.(*content.ContentService)
+	triageRules.StartRuleRefresh(context.Background(), rulesRefreshInterval)
+
+	// Topics are reloaded periodically as well as after admin edits
+	topicsRefreshInterval := 60 * time.Second
+	if v, err := strconv.Atoi(os.Getenv("TOPICS_REFRESH_SECONDS")); err == nil {
+		topicsRefreshInterval = time.Duration(v) * time.Second
+	}
+	triageRules.StartTopicRefresh(context.Background(), topicsRefreshInterval)
+	topicPublishConfig, err := config.LoadTopicPublishConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	topicUsecase := usecase.NewTopicUsecase(topicRepo, topicRevisionRepo, triageRules, topicPublishConfig)
+
+	// User-facing messages are loaded from per-language bundles and re-read periodically
+	messagesDir := os.Getenv("MESSAGES_DIR")
+	if messagesDir == "" {
+		messagesDir = "./data/locales"
+	}
+	messages, err := i18n.NewCatalog(messagesDir)
+	if err != nil {
+		log.Fatalf("❌ Failed to load message catalog: %v", err)
+	}
+	messagesReloadInterval := 60 * time.Second
+	if v, err := strconv.Atoi(os.Getenv("MESSAGES_RELOAD_SECONDS")); err == nil {
+		messagesReloadInterval = time.Duration(v) * time.Second
+	}
+	messages.StartReload(context.Background(), messagesReloadInterval)
+
+	// LLM prompts are versioned templates; the active versions are re-read periodically
+	promptRegistry, err := prompts.NewRegistry(promptsDir, promptRepo)
+	if err != nil {
+		log.Fatalf("❌ Failed to load prompt templates: %v", err)
+	}
+	promptsReloadInterval := 60 * time.Second
+	if v, err := strconv.Atoi(os.Getenv("PROMPTS_RELOAD_SECONDS")); err == nil {
+		promptsReloadInterval = time.Duration(v) * time.Second
+	}
+	promptRegistry.StartRefresh(context.Background(), promptsReloadInterval)
 
-	// Initialize Gemini LLM client
-	gemKey := os.Getenv("GEMINI_API_KEY")
-	if gemKey == "" {
-		log.Fatal("❌ GEMINI_API_KEY not set.")
+	// LLM providers in fallback order; each has its own retries, concurrency cap and
+	// circuit breaker. The service starts with whatever providers are configured.
+	providerConfigs, err := config.LoadLLMProviderConfigs()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	resilienceConfig, err := config.LoadLLMResilienceConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	routerConfig, err := config.LoadLLMRouterConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	llmRouter := llm.NewRouterClient(providerConfigs, resilienceConfig, routerConfig)
+	for _, provider := range llmRouter.ProviderStatus() {
+		if provider.Available {
+			log.Printf("✅ LLM provider %d: %s", provider.Priority, provider.Name)
+		} else {
+			log.Printf("⚠️ LLM provider %d: %s unavailable: %s", provider.Priority, provider.Name, provider.Reason)
+		}
+	}
+	if len(llmRouter.Live()) == 0 {
+		log.Printf("⚠️ No LLM provider available; triage runs on keyword rules only and AI features are degraded")
 	}
+	var llmClient interfaces.LLMClient = llmRouter
 
-	llmConfig := dto.LLMConfig{
-		APIKey:      gemKey,
-		Model:       os.Getenv("GEMINI_MODEL"),
-		MaxTokens:   150,
-		Temperature: 0.1,
-		Timeout:     30,
+	// Every provider call is recorded per feature; once the daily token budget is spent LLM features degrade
+	budgetConfig, err := config.LoadLLMBudgetConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	usageClient := llm.NewUsageClient(llmClient, llmUsageRepo, budgetConfig, llm.ProviderRouter, "")
+	if err := usageClient.Restore(context.Background()); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	llmClient = usageClient
+	if budgetConfig.DailyTokens > 0 {
+		log.Printf("✅ Daily LLM token budget: %d (used today: %d)", budgetConfig.DailyTokens, usageClient.BudgetStatus().UsedTokens)
 	}
 
-	geminiClient := llm.NewGeminiClient(llmConfig)
-	log.Printf("✅ Using Gemini LLM client (model=%s)", llmConfig.Model)
+	// Response cache in front of the others, so hits skip retries, the concurrency cap and the budget
+	cacheConfig, err := config.LoadLLMCacheConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	var llmCache interfaces.LLMCacheStatsProvider
+	if cacheConfig.Backend != llm.CacheBackendOff {
+		var cacheStore interfaces.LLMCacheStore = llm.NewMemoryCacheStore(cacheConfig.MaxEntries)
+		if cacheConfig.Backend == llm.CacheBackendMongo {
+			cacheStore = repository.NewLLMCacheRepository(cacheConfig.MaxEntries)
+		}
+		cachingClient := llm.NewCachingClient(llmClient, cacheStore, cacheConfig, llmRouter.Name())
+		llmClient = cachingClient
+		llmCache = cachingClient
+		log.Printf("✅ LLM response cache enabled (backend=%s, ttl=%s, max entries=%d)", cacheConfig.Backend, cacheConfig.TTL, cacheConfig.MaxEntries)
+	}
 
-	triageService := remedymate_services.NewTriageService(contentService, geminiClient)
-	guidanceComposer := guidance.NewGuidanceComposerService(contentService, geminiClient)
-	mapService := remedymate_services.NewMapTopicService(gemKey, os.Getenv("GEMINI_MODEL"))
-	conversationService := conversation.NewConversationService(geminiClient)
+	// Consensus sampling: each LLM-classified triage costs TRIAGE_CONSENSUS_SAMPLES calls
+	triageConfig, err := config.LoadTriageConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if triageConfig.ConsensusSamples > 1 {
+		log.Printf("✅ Triage consensus sampling enabled (samples=%d, temperatures=%v): up to %d LLM calls per triage",
+			triageConfig.ConsensusSamples, triageConfig.ConsensusTemperatures, triageConfig.ConsensusSamples)
+	}
+
+	triageService := remedymate_services.NewTriageService(contentService, llmClient, messages, promptRegistry, triageConfig)
+	guidanceComposer := guidance.NewGuidanceComposerService(contentService, llmClient)
+	// Topic mapping: BM25 over the approved content first, the LLM only for ambiguous input
+	topicMapperConfig, err := config.LoadTopicMapperConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	var mapService interfaces.MapTopicService
+	if topicMapperConfig.Mode != dto.TopicMapperLocal {
+		mapService = remedymate_services.NewMapTopicService(llmClient, promptRegistry)
+	}
+	var localTopicMapper interfaces.LocalTopicMapper
+	if topicMapperConfig.Mode != dto.TopicMapperLLM {
+		localMapper, err := remedymate_services.NewLocalTopicMapper(contentService, topicMapperConfig)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		localTopicMapper = localMapper
+	}
+	log.Printf("✅ Topic mapper mode: %s", topicMapperConfig.Mode)
+	conversationService := conversation.NewConversationService(llmClient, messages, promptRegistry)
 
 	// Initialize RemedyMate usecase
-	remedyMateUsecase := usecase.NewRemedyMateUsecase(triageService, contentService, guidanceComposer, mapService)
+	remedyMateUsecase := usecase.NewRemedyMateUsecase(triageService, contentService, guidanceComposer, mapService, localTopicMapper, triageAuditRepo)
 
 	// Initialize Conversation usecase
 	conversationUsecase := usecase.NewConversationUsecase(
 		conversationService,
 		conversationRepo,
 		remedyMateUsecase,
+		messages,
 	)
 
 	// Admin usecases
-	adminRedFlagUsecase := usecase.NewAdminRedFlagUsecase(redFlagRepo)
+	adminRedFlagUsecase := usecase.NewAdminRedFlagUsecase(redFlagRepo, triageRules)
 	adminFeedbackUsecase := usecase.NewAdminFeedbackUsecase(feedbackRepo)
+	adminTriageAuditUsecase := usecase.NewAdminTriageAuditUsecase(triageAuditRepo, triageRules)
+	adminLLMUsecase := usecase.NewAdminLLMUsecase(llmCache, llmUsageRepo, usageClient, budgetConfig, llmRouter)
+	adminPromptUsecase := usecase.NewAdminPromptUsecase(promptRepo, promptRegistry)
 
 	// Initialize controllers
 	authController := controllers.NewAuthController(authUsecase)
 	userController := controllers.NewUserController(userUsecase)
 	remedyMateController := controllers.NewRemedyMateController(remedyMateUsecase)
-	conversationController := controllers.NewConversationController(conversationUsecase)
+	conversationController := controllers.NewConversationController(conversationUsecase, messages)
 	topicController := controllers.NewTopicController(topicUsecase)
 	adminRedFlagController := controllers.NewAdminRedFlagController(adminRedFlagUsecase)
 	adminFeedbackController := controllers.NewAdminFeedbackController(adminFeedbackUsecase)
 	feedbackPublicController := controllers.NewFeedbackPublicController(publicFeedbackUsecase)
+	adminTriageAuditController := controllers.NewAdminTriageAuditController(adminTriageAuditUsecase)
+	adminLLMController := controllers.NewAdminLLMController(adminLLMUsecase)
+	adminPromptController := controllers.NewAdminPromptController(adminPromptUsecase)
 
 	// Setup router
 	r := routers.SetupRouter(
@@ -126,6 +293,9 @@ func main() {
 		adminRedFlagController,
 		adminFeedbackController,
 		feedbackPublicController,
+		adminTriageAuditController,
+		adminLLMController,
+		adminPromptController,
 	)
 
 	port := os.Getenv("PORT")
@@ -17,7 +17,12 @@ func SetupRouter(
 	topicController *controllers.TopicController,
 	adminRedFlagController *controllers.AdminRedFlagController,
 	adminFeedbackController *controllers.AdminFeedbackController,
-	feedbackPublicController *controllers.FeedbackPublicController) *gin.Engine {
+	feedbackPublicController *controllers.FeedbackPublicController,
+	adminTriageAuditController *controllers.AdminTriageAuditController,
+	adminLLMController *controllers.AdminLLMController,
+	adminPromptController *controllers.AdminPromptController) *gin.Engine {
+
+	RegisterValidators()
 
 	r := gin.Default()
 
@@ -73,9 +78,18 @@ func SetupRouter(
 			admin.GET("/users/profiles/paginated", middleware.SuperAdminMiddleware(), userController.GetUserProfilesPaginated)
 
 			admin.GET("/topics", topicController.ListAllTopicsHandler)
+			admin.GET("/topics/export", topicController.ExportTopicsHandler)
+			admin.POST("/topics/import", topicController.ImportTopicsHandler)
+			admin.GET("/topics/translation-report", topicController.TranslationReportHandler)
 			admin.POST("/topic", topicController.CreateTopicHandler)
 			admin.PUT("/topics/:topic_key", topicController.UpdateTopicHandler)
 			admin.DELETE("/topics/:topic_key", topicController.DeleteTopicHandler)
+			admin.POST("/topics/:topic_key/transitions", topicController.TransitionTopicHandler)
+			admin.GET("/topics/:topic_key/revisions", topicController.ListRevisionsHandler)
+			admin.GET("/topics/:topic_key/revisions/:version", topicController.GetRevisionHandler)
+			admin.POST("/topics/:topic_key/revisions/:version/rollback", topicController.RollbackTopicHandler)
+			admin.GET("/topics/:topic_key/diff", topicController.DiffRevisionsHandler)
+			admin.GET("/topics/:topic_key/served", topicController.ServedAtHandler)
 			admin.GET("/topic/:topic_key", topicController.GetTopicHandler)
 
 			// Redflags
@@ -89,6 +103,24 @@ func SetupRouter(
 			admin.GET("/feedbacks", adminFeedbackController.List)
 			admin.GET("/feedbacks/:id", adminFeedbackController.Get)
 			admin.DELETE("/feedbacks/:id", adminFeedbackController.Delete)
+
+			// Triage audit trail
+			admin.GET("/triage-audits", adminTriageAuditController.List)
+			admin.GET("/triage-audits/rule-stats", adminTriageAuditController.RuleStats)
+			admin.GET("/triage-audits/:id", adminTriageAuditController.Get)
+			admin.GET("/triage-rules/:version", adminTriageAuditController.GetRuleSnapshot)
+
+			// LLM operations
+			admin.GET("/llm/cache/stats", adminLLMController.CacheStats)
+			admin.GET("/llm/usage", adminLLMController.Usage)
+			admin.GET("/llm/providers", adminLLMController.Providers)
+
+			// Prompt templates
+			admin.GET("/prompts", adminPromptController.List)
+			admin.GET("/prompts/:name", adminPromptController.Versions)
+			admin.POST("/prompts/:name", adminPromptController.Create)
+			admin.GET("/prompts/:name/versions/:version", adminPromptController.Get)
+			admin.POST("/prompts/:name/versions/:version/activate", adminPromptController.Activate)
 		}
 	}
 
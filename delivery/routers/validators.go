@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"remedymate-backend/util/lang"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidators adds the custom binding tags used by request DTOs
+func RegisterValidators() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	// `binding:"language"` accepts any language enabled in the language registry
+	_ = v.RegisterValidation("language", func(fl validator.FieldLevel) bool {
+		return lang.IsSupported(fl.Field().String())
+	})
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/bootstrap"
+	"remedymate-backend/infrastructure/database"
+	"remedymate-backend/repository"
+	"remedymate-backend/usecase"
+	"remedymate-backend/util/lang"
+	"remedymate-backend/util/topicfile"
+
+	"github.com/joho/godotenv"
+)
+
+// Exports and imports topics in the topic collection, for translators working in spreadsheets,
+// and seeds it from data/approved_block.json.
+//
+//	go run ./delivery/topics export -format=csv -lang=am -out=topics-am.csv
+//	go run ./delivery/topics export -state=published -keys=fever,cough
+//	go run ./delivery/topics import -as=admin@example.com -dry-run topics-am.csv
+//	go run ./delivery/topics import -as=admin@example.com topics-am.csv
+//	go run ./delivery/topics seed
+//
+// Imported changes are saved as drafts by the admin named with -as and are published through
+// the usual review.
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️ Warning: .env file not found")
+	}
+	loadLanguages()
+
+	command, args := os.Args[1], os.Args[2:]
+	var err error
+	switch command {
+	case "export":
+		err = exportTopics(args)
+	case "import":
+		err = importTopics(args)
+	case "seed":
+		err = seedTopics(args)
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: topics export|import|seed [flags]; run a command with -h for its flags")
+	os.Exit(2)
+}
+
+// loadLanguages loads the language registry the same way the server does
+func loadLanguages() {
+	path := os.Getenv("LANGUAGES_CONFIG")
+	if path == "" {
+		path = "config/languages.json"
+	}
+	registry, err := lang.LoadRegistry(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Fatalf("❌ Invalid language configuration: %v", err)
+		}
+		return
+	}
+	lang.SetDefault(registry)
+}
+
+func newTopicUsecase() (*usecase.TopicUsecase, error) {
+	database.ConnectMongo()
+	topicRepo, err := repository.NewTopicRepository()
+	if err != nil {
+		return nil, err
+	}
+	// The server picks up published changes on its next topic refresh
+	return usecase.NewTopicUsecase(topicRepo, repository.NewTopicRevisionRepository(), nil, dto.TopicPublishConfig{}), nil
+}
+
+func exportTopics(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", "json", "file format: json or csv")
+	out := flags.String("out", "", "file to write (default stdout)")
+	state := flags.String("state", "", "only topics in this editorial state")
+	keys := flags.String("keys", "", "comma separated topic keys to export")
+	languages := flags.String("lang", "", "comma separated languages to export (default all)")
+	_ = flags.Parse(args)
+
+	fileFormat, err := topicfile.FormatOf(*format, "")
+	if err != nil {
+		return err
+	}
+	topics, err := newTopicUsecase()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	records, err := topics.ExportTopics(ctx, dto.TopicExportQuery{
+		State:     *state,
+		TopicKeys: splitList(*keys),
+		Languages: splitList(*languages),
+	})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := topicfile.Write(w, fileFormat, records); err != nil {
+		return err
+	}
+	log.Printf("✅ Exported %d topics", len(records))
+	return nil
+}
+
+func importTopics(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "file format: json or csv (default from the file extension)")
+	as := flags.String("as", "", "email of the admin the drafts are saved for (required)")
+	dryRun := flags.Bool("dry-run", false, "report what would change without writing")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 || *as == "" {
+		return fmt.Errorf("usage: topics import -as=<admin email> [-dry-run] [-format=json|csv] <file>")
+	}
+
+	fileFormat, err := topicfile.FormatOf(*format, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	records, err := topicfile.Read(file, fileFormat)
+	if err != nil {
+		return err
+	}
+
+	topics, err := newTopicUsecase()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	admin, err := repository.NewUserRepository().FindByEmail(ctx, *as)
+	if err != nil || admin == nil {
+		return fmt.Errorf("admin %s not found", *as)
+	}
+	// Drafts are only saved for someone who could have made them through the admin API
+	if admin.Role != entities.RoleAdmin && admin.Role != entities.RoleSuperAdmin {
+		return fmt.Errorf("%s is not an admin", *as)
+	}
+	report, err := topics.ImportTopics(context.WithValue(ctx, "userID", admin.ID), records, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged, %d invalid", report.Created, report.Updated, report.Unchanged, report.Invalid)
+	switch {
+	case report.Invalid > 0:
+		return fmt.Errorf("nothing imported: %s", summary)
+	case !report.Applied:
+		log.Printf("✅ Dry run: %s", summary)
+	default:
+		log.Printf("✅ Imported as drafts: %s", summary)
+	}
+	return nil
+}
+
+func seedTopics(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	dataPath := flags.String("data", "data", "directory with approved_block.json")
+	_ = flags.Parse(args)
+
+	database.ConnectMongo()
+	topicRepo, err := repository.NewTopicRepository()
+	if err != nil {
+		return err
+	}
+	return bootstrap.SeedTopics(topicRepo, repository.NewTopicRevisionRepository(), *dataPath)
+}
+
+// splitList splits a comma separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"remedymate-backend/config"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/database"
+	"remedymate-backend/infrastructure/i18n"
+	"remedymate-backend/infrastructure/llm"
+	"remedymate-backend/infrastructure/prompts"
+	"remedymate-backend/infrastructure/remedymate_services"
+	"remedymate-backend/repository"
+	"remedymate-backend/util/triageeval"
+
+	"github.com/joho/godotenv"
+)
+
+// Runs the labeled triage dataset through TriageService.ClassifySymptoms and
+// reports how the classifications compare with the expected levels.
+//
+//	go run ./delivery/triage_eval                       # scripted fake LLM, rules from data/*.json
+//	go run ./delivery/triage_eval -llm=live             # real calls to the LLM_PROVIDER from the environment
+//	go run ./delivery/triage_eval -rules=mongo          # rules from the redflags collection
+//	go run ./delivery/triage_eval -samples=3            # consensus sampling
+//	go run ./delivery/triage_eval -min-red-recall=1.0   # non-zero exit when RED recall drops
+func main() {
+	datasetPath := flag.String("dataset", "data/triage_eval/golden.json", "labeled dataset of symptom texts")
+	dataPath := flag.String("data", "data", "directory with approved blocks and flag rule files")
+	llmMode := flag.String("llm", "scripted", "LLM client to use: scripted or live (configured by LLM_PROVIDER)")
+	scriptPath := flag.String("responses", "data/triage_eval/scripted_responses.json", "scripted responses for -llm=scripted")
+	rulesSource := flag.String("rules", "json", "triage rule source: json or mongo")
+	jsonOut := flag.String("json", "", "optional path to write the report as JSON")
+	minRedRecall := flag.Float64("min-red-recall", 0, "exit with status 1 when overall RED recall is below this value (0-1)")
+	samples := flag.Int("samples", 0, "consensus samples per case (default TRIAGE_CONSENSUS_SAMPLES or 1)")
+	temperatures := flag.String("temperatures", "", "comma separated consensus temperatures (default TRIAGE_CONSENSUS_TEMPERATURES)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️ Warning: .env file not found")
+	}
+
+	triageConfig, err := config.LoadTriageConfig()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if *samples > 0 {
+		triageConfig.ConsensusSamples = *samples
+	}
+	if *temperatures != "" {
+		if triageConfig.ConsensusTemperatures, err = config.ParseTemperatures(*temperatures); err != nil {
+			log.Fatalf("❌ Invalid -temperatures: %v", err)
+		}
+	}
+
+	cases, err := loadDataset(*datasetPath)
+	if err != nil {
+		log.Fatal("❌ Failed to load dataset:", err)
+	}
+
+	var redFlagRepo interfaces.RedFlagRepository
+	if *rulesSource == "mongo" {
+		database.ConnectMongo()
+		redFlagRepo = repository.NewRedFlagRepository()
+	} else if *rulesSource != "json" {
+		log.Fatalf("❌ Unknown rule source: %s", *rulesSource)
+	}
+	contentService := content.NewContentService(*dataPath, redFlagRepo, nil)
+
+	llmClient, err := newLLMClient(*llmMode, *scriptPath)
+	if err != nil {
+		log.Fatal("❌ Failed to create LLM client:", err)
+	}
+	messages, err := i18n.NewCatalog(filepath.Join(*dataPath, "locales"))
+	if err != nil {
+		log.Fatal("❌ Failed to load message catalog:", err)
+	}
+	// The newest prompt template files are evaluated, so a new version can be checked before it is activated
+	promptRegistry, err := prompts.NewRegistry(filepath.Join(*dataPath, "prompts"), nil)
+	if err != nil {
+		log.Fatal("❌ Failed to load prompt templates:", err)
+	}
+	triageService := remedymate_services.NewTriageService(contentService, llmClient, messages, promptRegistry, triageConfig)
+
+	results := make([]triageeval.CaseResult, 0, len(cases))
+	for _, c := range cases {
+		results = append(results, runCase(triageService, c))
+	}
+
+	report := triageeval.BuildReport(results)
+	if rp, ok := contentService.(interfaces.TriageRuleProvider); ok {
+		report.RulesVersion = rp.RulesVersion()
+	}
+	report.LLM = *llmMode
+	report.Samples = triageConfig.ConsensusSamples
+	triageeval.PrintReport(os.Stdout, report)
+
+	if *jsonOut != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal("❌ Failed to encode report:", err)
+		}
+		if err := os.WriteFile(*jsonOut, data, 0o644); err != nil {
+			log.Fatal("❌ Failed to write report:", err)
+		}
+		fmt.Printf("✅ Report written to %s\n", *jsonOut)
+	}
+
+	if *minRedRecall > 0 && report.RedRecall < *minRedRecall {
+		fmt.Printf("❌ RED recall %.3f is below the required %.3f\n", report.RedRecall, *minRedRecall)
+		os.Exit(1)
+	}
+}
+
+// loadDataset reads the labeled cases from a JSON file
+func loadDataset(path string) ([]triageeval.EvalCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cases []triageeval.EvalCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("failed to parse dataset: %w", err)
+	}
+	for i, c := range cases {
+		switch c.Expected {
+		case entities.TriageLevelRed, entities.TriageLevelYellow, entities.TriageLevelGreen:
+		default:
+			return nil, fmt.Errorf("case %d (%s): invalid expected level %q", i, c.ID, c.Expected)
+		}
+	}
+	return cases, nil
+}
+
+// newLLMClient builds the LLM client selected on the command line
+func newLLMClient(mode, scriptPath string) (interfaces.LLMClient, error) {
+	switch mode {
+	case "scripted":
+		return llm.LoadScriptedClient(scriptPath)
+	case "live":
+		llmConfig, err := config.LoadLLMConfig()
+		if err != nil {
+			return nil, err
+		}
+		resilienceConfig, err := config.LoadLLMResilienceConfig()
+		if err != nil {
+			return nil, err
+		}
+		client, err := llm.NewClient(llmConfig)
+		if err != nil {
+			return nil, err
+		}
+		return llm.NewResilientClient(client, resilienceConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM client: %s", mode)
+	}
+}
+
+// runCase classifies one case, recording errors instead of aborting the run
+func runCase(triageService interfaces.TriageService, c triageeval.EvalCase) triageeval.CaseResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
+
+	res := triageeval.CaseResult{Case: c}
+	result, err := triageService.ClassifySymptoms(ctx, c.Text, c.Language, c.Patient)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Got = result.Level
+	res.Flags = result.RedFlags
+	res.Agreement = result.Agreement
+	res.LLMCalls = result.LLMCalls
+	if result.Trace != nil {
+		res.Source = result.Trace.Source
+	}
+	return res
+}
@@ -11,6 +11,17 @@ var (
 	ErrInvalidInput         = errors.New("invalid input")
 	ErrTopicAlreadyExists   = errors.New("topic already exists")
 
+	// topic editorial and revision errors
+	ErrInvalidTopicTransition = errors.New("invalid topic state transition")
+	ErrSelfReview             = errors.New("a topic change must be approved by someone other than its authors")
+	ErrTopicRevisionNotFound  = errors.New("topic revision not found")
+	ErrTopicRevisionExists    = errors.New("topic revision already exists")
+	ErrIncompleteTranslation  = errors.New("topic translations are incomplete")
+
+	// triage audit errors
+	ErrTriageAuditNotFound  = errors.New("triage audit record not found")
+	ErrRuleSnapshotNotFound = errors.New("triage rule snapshot not found")
+
 	// user errors
 	ErrUserNotFound         = errors.New("user not found")
 	ErrUserNotAuthenticated = errors.New("user not authenticated")
@@ -28,6 +39,13 @@ var (
 	ErrRefreshTokenNotFound   = errors.New("refresh token not found")
 	ErrInvalidActivationToken = errors.New("invalid or expired activation token")
 
+	// LLM errors
+	ErrLLMDegraded = errors.New("the AI service is temporarily unavailable, please try again shortly")
+
+	// prompt template errors
+	ErrPromptTemplateNotFound = errors.New("prompt template not found")
+	ErrInvalidPromptTemplate  = errors.New("invalid prompt template")
+
 	// server errors
 	ErrInternalServer = errors.New("internal server error")
 )
@@ -1,15 +1,18 @@
 package dto
 
 type CreateRedFlagDTO struct {
-	Keywords    []string `json:"keywords" binding:"required,min=1"`
-	Language    string   `json:"language" binding:"required,oneof=en am"`
-	Level       string   `json:"level" binding:"required,oneof=RED YELLOW"`
-	Description string   `json:"description" binding:"required,min=3"`
+	Keywords    []string        `json:"keywords" binding:"required,min=1"`
+	Language    string          `json:"language" binding:"required,language"`
+	Level       string          `json:"level" binding:"required,oneof=RED YELLOW"`
+	Description string          `json:"description" binding:"required,min=3"`
+	Conditions  *RuleConditions `json:"conditions,omitempty"`
 }
 
 type UpdateRedFlagDTO struct {
 	Keywords    []string `json:"keywords" binding:"omitempty,min=1"`
-	Language    string   `json:"language" binding:"omitempty,oneof=en am"`
+	Language    string   `json:"language" binding:"omitempty,language"`
 	Level       string   `json:"level" binding:"omitempty,oneof=RED YELLOW"`
 	Description string   `json:"description" binding:"omitempty,min=3"`
+	// Conditions replace the current ones when present; send {} to remove them
+	Conditions *RuleConditions `json:"conditions,omitempty"`
 }
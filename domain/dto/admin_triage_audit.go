@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"remedymate-backend/domain/entities"
+)
+
+// TriageAuditFilter narrows the triage audit search for clinical review
+type TriageAuditFilter struct {
+	Level    string     // RED, YELLOW or GREEN; empty for all
+	Language string     // language code; empty for all
+	From     *time.Time // inclusive lower bound on createdAt
+	To       *time.Time // exclusive upper bound on createdAt
+	Limit    int
+	Offset   int
+}
+
+// TriageRuleStat counts how often a red/yellow flag rule fired in recorded triage decisions
+type TriageRuleStat struct {
+	RuleID        string               `json:"ruleId" bson:"_id"`
+	Description   string               `json:"description" bson:"description"`
+	Level         entities.TriageLevel `json:"level" bson:"level"`
+	Language      string               `json:"language,omitempty" bson:"-"`
+	Count         int64                `json:"count" bson:"count"`
+	KeywordCount  int64                `json:"keywordCount" bson:"keywordCount"`
+	LLMCount      int64                `json:"llmCount" bson:"llmCount"`
+	LastMatchedAt *time.Time           `json:"lastMatchedAt,omitempty" bson:"lastMatchedAt,omitempty"`
+}
@@ -23,6 +23,7 @@ type TopicListQueryParams struct {
 	PaginationQueryParams
 	FilterQueryParams
 	SortQueryParams
+	State string `json:"state"` // editorial state, e.g. "in_review" for the review queue
 }
 
 // PaginationMetadata contains metadata for paginated responses
@@ -6,9 +6,10 @@ import (
 
 // StartConversationRequest represents the request to start a new conversation
 type StartConversationRequest struct {
-	Symptom  string `json:"symptom" binding:"required" validate:"min=3,max=500"`
-	Language string `json:"language" binding:"required" validate:"oneof=en am"`
-	UserID   string `json:"user_id,omitempty"` // Optional, for unauthenticated users
+	Symptom  string          `json:"symptom" binding:"required" validate:"min=3,max=500"`
+	Language string          `json:"language" binding:"required,language"`
+	UserID   string          `json:"user_id,omitempty"` // Optional, for unauthenticated users
+	Patient  *PatientContext `json:"patient,omitempty"`
 }
 
 // StartConversationResponse represents the response when starting a conversation
@@ -30,6 +31,7 @@ type SubmitAnswerResponse struct {
 	ConversationID string             `json:"conversation_id"`
 	Question       *entities.Question `json:"question,omitempty"` // Next question if available
 	Message        string             `json:"message,omitempty"`  // Feedback message for invalid answers
+	Language       string             `json:"language"`           // Language of the conversation
 	IsComplete     bool               `json:"is_complete"`        // Whether all questions are answered
 	CurrentStep    int                `json:"current_step"`
 	TotalSteps     int                `json:"total_steps"`
@@ -54,11 +56,12 @@ type ConversationError struct {
 
 // ConversationRequest represents a unified request for both starting and continuing conversations
 type ConversationRequest struct {
-	ConversationID string `json:"conversation_id,omitempty"` // Required for continuing, optional for starting
-	Symptom        string `json:"symptom,omitempty"`         // Required for starting, optional for continuing
-	Language       string `json:"language,omitempty"`        // Required for starting, optional for continuing
-	Answer         string `json:"answer,omitempty"`          // Required for continuing, optional for starting
-	UserID         string `json:"user_id,omitempty"`         // Optional for both
+	ConversationID string          `json:"conversation_id,omitempty"` // Required for continuing, optional for starting
+	Symptom        string          `json:"symptom,omitempty"`         // Required for starting, optional for continuing
+	Language       string          `json:"language,omitempty"`        // Required for starting, optional for continuing
+	Answer         string          `json:"answer,omitempty"`          // Required for continuing, optional for starting
+	UserID         string          `json:"user_id,omitempty"`         // Optional for both
+	Patient        *PatientContext `json:"patient,omitempty"`         // Optional, used when starting
 }
 
 // ConversationResponse represents a unified response for both starting and continuing conversations
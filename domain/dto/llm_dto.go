@@ -1,16 +1,217 @@
 package dto
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 // LLMConfig holds configuration for LLM client
 type LLMConfig struct {
+	// Name identifies the provider in a fallback chain, "<provider>/<model>" unless set
+	Name        string
+	Provider    string // gemini, openai or ollama
 	APIKey      string
 	Model       string
-	MaxTokens   int
+	BaseURL     string // provider endpoint; empty uses the provider's default
+	MaxTokens   int    // default when a request does not set MaxTokens
 	Temperature float32
 	Timeout     int // seconds
 }
 
+// LLMResilienceConfig controls retries, concurrency and the circuit breaker in front of the LLM provider
+type LLMResilienceConfig struct {
+	// MaxRetries is how many times a retryable failure is repeated (0 disables retries)
+	MaxRetries int
+	// BaseDelay doubles on every retry up to MaxDelay; the actual wait is jittered.
+	// A provider asking for a longer Retry-After than MaxDelay is not retried.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxConcurrent caps in-flight provider calls (0 is unlimited)
+	MaxConcurrent int
+	// FailureThreshold consecutive failed calls open the circuit for OpenDuration (0 disables the breaker)
+	FailureThreshold int
+	OpenDuration     time.Duration
+}
+
+// LLMRouterConfig controls failover across the configured providers
+type LLMRouterConfig struct {
+	// AttemptTimeout bounds the time one provider gets, retries included, before the
+	// next one is tried (0 leaves it to the provider's own timeout)
+	AttemptTimeout time.Duration
+}
+
+// LLMProviderStatus is the health of one provider in the fallback chain
+type LLMProviderStatus struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	// Priority is the position in the chain, 1 is tried first
+	Priority int `json:"priority"`
+	// Available is false when the provider could not be set up, e.g. a missing API key
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+	// Live is set when the provider is available and its circuit breaker is not open
+	Live                bool       `json:"live"`
+	Circuit             string     `json:"circuit,omitempty"`
+	Calls               int64      `json:"calls"`
+	Failures            int64      `json:"failures"`
+	ConsecutiveFailures int64      `json:"consecutiveFailures"`
+	AvgLatencyMs        float64    `json:"avgLatencyMs"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastErrorAt         *time.Time `json:"lastErrorAt,omitempty"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+}
+
+// LLM message roles
+const (
+	LLMRoleUser      = "user"
+	LLMRoleAssistant = "assistant"
+)
+
+// LLM features, used to attribute token usage, as response cache scopes and as prompt template names
+const (
+	LLMFeatureTriage            = "triage"
+	LLMFeatureMapTopic          = "map_topic"
+	LLMFeatureValidateSymptom   = "validate_symptom"
+	LLMFeatureGenerateQuestions = "generate_questions"
+	LLMFeatureValidateAnswer    = "validate_answer"
+	LLMFeatureReport            = "report"
+)
+
+// LLMMessage is one turn of the conversation sent to the model
+type LLMMessage struct {
+	Role    string
+	Content string
+}
+
+// GenerateRequest is a provider-neutral LLM call. Zero values fall back to the client's configuration.
+type GenerateRequest struct {
+	// System holds the instructions; user-provided text belongs in Messages
+	System   string
+	Messages []LLMMessage
+	// JSONMode asks the provider to return a JSON document
+	JSONMode      bool
+	MaxTokens     int
+	Temperature   *float32
+	StopSequences []string
+	// Feature names the calling feature for usage accounting (one of the LLMFeature constants)
+	Feature string
+	// CacheScope opts the call into the response cache (e.g. "triage"); empty is never cached
+	CacheScope string
+	// CacheVersion identifies the data behind the prompt, such as the triage rules version.
+	// It is part of the key; a process that moves to a new version drops the scope's older entries.
+	CacheVersion string
+	// PromptVersion is the template the prompt was rendered from (e.g. "triage@v2"), kept in the usage log
+	PromptVersion string
+}
+
+// PromptRequest is a single user message with the given instructions
+func PromptRequest(system, prompt string) GenerateRequest {
+	return GenerateRequest{
+		System:   system,
+		Messages: []LLMMessage{{Role: LLMRoleUser, Content: prompt}},
+	}
+}
+
+// LLMUsage is the token count reported by the provider
+type LLMUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// GenerateResponse is the model's reply
+type GenerateResponse struct {
+	Text  string
+	Model string
+	// Provider names the provider in the fallback chain that served the reply
+	Provider     string
+	FinishReason string
+	Usage        LLMUsage
+	// Cached is set when the reply came from the response cache; Usage is then zero
+	Cached bool
+}
+
+// LLMCacheConfig selects the response cache backend and its limits
+type LLMCacheConfig struct {
+	Backend    string // memory, mongo or off
+	TTL        time.Duration
+	MaxEntries int // 0 is unlimited
+}
+
+// LLMCacheStats reports response cache effectiveness since startup
+type LLMCacheStats struct {
+	Backend string                        `json:"backend"`
+	Entries int64                         `json:"entries"`
+	Hits    int64                         `json:"hits"`
+	Misses  int64                         `json:"misses"`
+	HitRate float64                       `json:"hitRate"`
+	Scopes  map[string]LLMCacheScopeStats `json:"scopes"`
+}
+
+// LLMBudgetConfig caps provider usage per UTC day and prices it for reporting
+type LLMBudgetConfig struct {
+	// DailyTokens is the total token budget per UTC day; 0 is unlimited
+	DailyTokens int64
+	// Prices per 1000 tokens, in the billing currency, for the cost estimate
+	PromptPricePer1K     float64
+	CompletionPricePer1K float64
+}
+
+// LLMUsageFilter narrows the daily usage report
+type LLMUsageFilter struct {
+	Feature string     // empty for all
+	From    *time.Time // inclusive lower bound on createdAt
+	To      *time.Time // exclusive upper bound on createdAt
+}
+
+// LLMUsageDaily aggregates provider calls for one UTC day, feature, prompt version and model
+type LLMUsageDaily struct {
+	Date             string  `json:"date" bson:"date"`
+	Feature          string  `json:"feature" bson:"feature"`
+	PromptVersion    string  `json:"promptVersion,omitempty" bson:"promptVersion,omitempty"`
+	Model            string  `json:"model" bson:"model"`
+	Calls            int64   `json:"calls" bson:"calls"`
+	Failures         int64   `json:"failures" bson:"failures"`
+	PromptTokens     int64   `json:"promptTokens" bson:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens" bson:"completionTokens"`
+	TotalTokens      int64   `json:"totalTokens" bson:"totalTokens"`
+	AvgLatencyMs     float64 `json:"avgLatencyMs" bson:"avgLatencyMs"`
+	EstimatedCost    float64 `json:"estimatedCost" bson:"-"`
+}
+
+// LLMBudgetStatus is today's token use against the daily budget
+type LLMBudgetStatus struct {
+	Date        string `json:"date"`
+	DailyTokens int64  `json:"dailyTokens"` // 0 is unlimited
+	UsedTokens  int64  `json:"usedTokens"`
+	Remaining   int64  `json:"remaining,omitempty"`
+	Exceeded    bool   `json:"exceeded"`
+	// RefusedCalls counts calls turned away today because the budget was spent
+	RefusedCalls int64 `json:"refusedCalls"`
+}
+
+// LLMUsageReport is the admin view of token usage
+type LLMUsageReport struct {
+	Items  []LLMUsageDaily `json:"items"`
+	Budget LLMBudgetStatus `json:"budget"`
+}
+
+// LLMCacheScopeStats is the hit/miss count for one cache scope
+type LLMCacheScopeStats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hitRate"`
+}
+
+// TriageConfig holds per-deployment triage settings
+type TriageConfig struct {
+	// ConsensusSamples is how many times the LLM classifies each input; 1 or less is single-shot
+	ConsensusSamples int
+	// ConsensusTemperatures are used round-robin across samples; empty keeps the client's temperature
+	ConsensusTemperatures []float32
+}
+
 // GeminiClient implements LLMClient using Gemini API
 type GeminiClient struct {
 	config     LLMConfig
@@ -20,9 +221,10 @@ type GeminiClient struct {
 
 // Gemini-specific request/response structures
 type GeminiRequest struct {
-	Contents         []GeminiContent       `json:"contents"`
-	GenerationConfig GeminiGenConfig       `json:"generationConfig"`
-	SafetySettings   []GeminiSafetySetting `json:"safetySettings,omitempty"`
+	SystemInstruction *GeminiContent        `json:"systemInstruction,omitempty"`
+	Contents          []GeminiContent       `json:"contents"`
+	GenerationConfig  GeminiGenConfig       `json:"generationConfig"`
+	SafetySettings    []GeminiSafetySetting `json:"safetySettings,omitempty"`
 }
 
 type GeminiContent struct {
@@ -35,9 +237,11 @@ type GeminiPart struct {
 }
 
 type GeminiGenConfig struct {
-	Temperature     float32 `json:"temperature"`
-	MaxOutputTokens int     `json:"maxOutputTokens"`
-	TopP            float32 `json:"topP"`
+	Temperature      float32  `json:"temperature"`
+	MaxOutputTokens  int      `json:"maxOutputTokens"`
+	TopP             float32  `json:"topP"`
+	StopSequences    []string `json:"stopSequences,omitempty"`
+	ResponseMimeType string   `json:"responseMimeType,omitempty"`
 }
 
 type GeminiSafetySetting struct {
@@ -46,12 +250,78 @@ type GeminiSafetySetting struct {
 }
 
 type GeminiResponse struct {
-	Candidates []GeminiCandidate `json:"candidates"`
-	Error      *APIError         `json:"error,omitempty"`
+	Candidates    []GeminiCandidate    `json:"candidates"`
+	UsageMetadata *GeminiUsageMetadata `json:"usageMetadata,omitempty"`
+	ModelVersion  string               `json:"modelVersion,omitempty"`
+	Error         *APIError            `json:"error,omitempty"`
 }
 
 type GeminiCandidate struct {
-	Content GeminiContent `json:"content"`
+	Content      GeminiContent `json:"content"`
+	FinishReason string        `json:"finishReason,omitempty"`
+}
+
+type GeminiUsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	TotalTokenCount      int `json:"totalTokenCount"`
+}
+
+// OpenAI-compatible chat completion structures
+type OpenAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []OpenAIChatMessage   `json:"messages"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	Temperature    float32               `json:"temperature"`
+	Stop           []string              `json:"stop,omitempty"`
+	ResponseFormat *OpenAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type OpenAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type OpenAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type OpenAIChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      OpenAIChatMessage `json:"message"`
+		FinishReason string            `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *APIError `json:"error,omitempty"`
+}
+
+// Ollama chat structures
+type OllamaChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []OpenAIChatMessage `json:"messages"`
+	Stream   bool                `json:"stream"`
+	Format   string              `json:"format,omitempty"`
+	Options  OllamaOptions       `json:"options"`
+}
+
+type OllamaOptions struct {
+	Temperature float32  `json:"temperature"`
+	NumPredict  int      `json:"num_predict,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+}
+
+type OllamaChatResponse struct {
+	Model           string            `json:"model"`
+	Message         OpenAIChatMessage `json:"message"`
+	DoneReason      string            `json:"done_reason"`
+	PromptEvalCount int               `json:"prompt_eval_count"`
+	EvalCount       int               `json:"eval_count"`
+	Error           string            `json:"error,omitempty"`
 }
 
 type APIError struct {
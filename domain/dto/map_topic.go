@@ -8,3 +8,34 @@ type MapTopicRequest struct {
 type MapTopicResponse struct {
 	TopicKey string `json:"topic_key"`
 }
+
+// TopicCandidate is a topic the local mapper matched, with its BM25 score
+type TopicCandidate struct {
+	TopicKey string  `json:"topic_key"`
+	Score    float64 `json:"score"`
+}
+
+// TopicRanking is the local mapper's answer, candidates best first
+type TopicRanking struct {
+	Candidates []TopicCandidate `json:"candidates"`
+	// Usable is set when the best candidate scores well enough to fall back on if the LLM is down
+	Usable bool `json:"usable"`
+	// Confident is set when the best candidate is also clearly ahead, so the LLM is not asked
+	Confident bool `json:"confident"`
+}
+
+// Topic mapper modes
+const (
+	TopicMapperHybrid = "hybrid" // local when confident, otherwise the LLM, local again if the LLM fails
+	TopicMapperLLM    = "llm"
+	TopicMapperLocal  = "local"
+)
+
+// TopicMapperConfig selects how symptoms are mapped to topics and when a local match is confident
+type TopicMapperConfig struct {
+	Mode string
+	// MinScore is the BM25 score the best candidate needs
+	MinScore float64
+	// MinMargin is how far, as a fraction of its score, the best candidate must lead the second
+	MinMargin float64
+}
@@ -0,0 +1,69 @@
+package dto
+
+import "remedymate-backend/domain/entities"
+
+// PatientContext is the optional patient information accepted with symptoms
+type PatientContext struct {
+	AgeBand           string   `json:"age_band,omitempty" binding:"omitempty,oneof=infant child adolescent adult older_adult"`
+	Pregnancy         string   `json:"pregnancy,omitempty" binding:"omitempty,oneof=pregnant not_pregnant unknown"`
+	ChronicConditions []string `json:"chronic_conditions,omitempty" binding:"omitempty,max=10,dive,min=2,max=50"`
+}
+
+// ToEntity converts the request context, returning nil when nothing was given
+func (p *PatientContext) ToEntity() *entities.PatientContext {
+	if p == nil {
+		return nil
+	}
+	pc := &entities.PatientContext{
+		AgeBand:   entities.AgeBand(p.AgeBand),
+		Pregnancy: entities.PregnancyStatus(p.Pregnancy),
+	}
+	for _, c := range p.ChronicConditions {
+		if c = entities.NormalizeCondition(c); c != "" {
+			pc.ChronicConditions = append(pc.ChronicConditions, c)
+		}
+	}
+	if pc.IsEmpty() {
+		return nil
+	}
+	return pc
+}
+
+// NewPatientContext converts a stored patient context back into request form
+func NewPatientContext(pc *entities.PatientContext) *PatientContext {
+	if pc.IsEmpty() {
+		return nil
+	}
+	return &PatientContext{
+		AgeBand:           string(pc.AgeBand),
+		Pregnancy:         string(pc.Pregnancy),
+		ChronicConditions: pc.ChronicConditions,
+	}
+}
+
+// RuleConditions is the admin input for restricting a rule to some patients
+type RuleConditions struct {
+	AgeBands          []string `json:"ageBands,omitempty" binding:"omitempty,dive,oneof=infant child adolescent adult older_adult"`
+	Pregnant          bool     `json:"pregnant,omitempty"`
+	ChronicConditions []string `json:"chronicConditions,omitempty" binding:"omitempty,dive,min=2,max=50"`
+}
+
+// ToEntity converts the conditions, returning nil when they place no restriction
+func (c *RuleConditions) ToEntity() *entities.RuleConditions {
+	if c == nil {
+		return nil
+	}
+	rc := &entities.RuleConditions{Pregnant: c.Pregnant}
+	for _, band := range c.AgeBands {
+		rc.AgeBands = append(rc.AgeBands, entities.AgeBand(band))
+	}
+	for _, condition := range c.ChronicConditions {
+		if condition = entities.NormalizeCondition(condition); condition != "" {
+			rc.ChronicConditions = append(rc.ChronicConditions, condition)
+		}
+	}
+	if rc.IsEmpty() {
+		return nil
+	}
+	return rc
+}
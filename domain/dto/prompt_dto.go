@@ -0,0 +1,35 @@
+package dto
+
+// RenderedPrompt is a prompt template executed with its data
+type RenderedPrompt struct {
+	Name    string
+	Version int
+	// Ref identifies the template version in usage logs and audits, e.g. "triage@v2"
+	Ref    string
+	System string
+	User   string
+}
+
+// Request builds an LLM request from the rendered prompt, tagged with its template version
+func (p *RenderedPrompt) Request() GenerateRequest {
+	request := PromptRequest(p.System, p.User)
+	request.PromptVersion = p.Ref
+	return request
+}
+
+// PromptTemplateSummary describes a prompt and its active version
+type PromptTemplateSummary struct {
+	Name          string   `json:"name"`
+	Fields        []string `json:"fields"`
+	ActiveVersion int      `json:"activeVersion"`
+	LatestVersion int      `json:"latestVersion"`
+	Versions      int      `json:"versions"`
+}
+
+// CreatePromptVersionDTO adds a new, inactive version of a prompt
+type CreatePromptVersionDTO struct {
+	Body        string `json:"body" binding:"required"`
+	Description string `json:"description"`
+	// Activate makes the new version live immediately
+	Activate bool `json:"activate"`
+}
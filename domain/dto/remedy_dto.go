@@ -5,15 +5,21 @@ import "remedymate-backend/domain/entities"
 // TriageRequest represents the request for symptom triage
 type RemedyRequest struct {
 	Text     string `json:"text" binding:"required" validate:"min=3,max=500"`
-	Language string `json:"language" binding:"required" validate:"oneof=en am"`
+	Language string `json:"language" binding:"required,language"`
+	// Patient is optional context that lets triage rules escalate, e.g. for infants or pregnancy
+	Patient *PatientContext `json:"patient,omitempty"`
 }
 
 // TriageResponse represents the response from triage
 type TriageResponse struct {
-	Level     entities.TriageLevel `json:"level"`
-	RedFlags  []string             `json:"red_flags"`
-	Message   string               `json:"message"`
-	SessionID string               `json:"session_id,omitempty"` // ?
+	Level    entities.TriageLevel `json:"level"`
+	RedFlags []string             `json:"red_flags"`
+	// MatchedRules tells the user which rules fired and which of their words triggered them
+	MatchedRules []entities.RuleMatch `json:"matched_rules,omitempty"`
+	Message      string               `json:"message"`
+	SessionID    string               `json:"session_id,omitempty"` // ?
+	Agreement    float64              `json:"agreement,omitempty"`
+	LLMCalls     int                  `json:"llm_calls,omitempty"`
 }
 
 type RemedyResponse struct {
@@ -1,6 +1,10 @@
 package dto
 
-import "remedymate-backend/domain/entities"
+import (
+	"time"
+
+	"remedymate-backend/domain/entities"
+)
 
 // PaginatedTopicsResult represents a paginated list of topics.
 type PaginatedTopicsResult struct {
@@ -17,6 +21,7 @@ type TopicCreateRequest struct {
 	NameAM            string                                       `json:"name_am"`
 	DescriptionEN     string                                       `json:"description_en,omitempty"`
 	DescriptionAM     string                                       `json:"description_am,omitempty"`
+	Synonyms          map[string][]string                          `json:"synonyms,omitempty"` // Everyday phrasings per language for topic mapping
 	IsOfflineCachable bool                                         `json:"is_offline_cachable"`
 	Translations      map[string]entities.LocalizedGuidanceContent `json:"translations"` // Full content for initial creation
 }
@@ -27,7 +32,150 @@ type TopicUpdateRequest struct {
 	NameAM            string                                       `json:"name_am,omitempty"`
 	DescriptionEN     string                                       `json:"description_en,omitempty"`
 	DescriptionAM     string                                       `json:"description_am,omitempty"`
+	Synonyms          map[string][]string                          `json:"synonyms,omitempty"`
 	IsOfflineCachable *bool                                        `json:"is_offline_cachable,omitempty"` // Pointer for explicit zero-value update
 	Status            *entities.TopicStatus                        `json:"status,omitempty"`              // Pointer for explicit zero-value update
 	Translations      map[string]entities.LocalizedGuidanceContent `json:"translations,omitempty"`        // Allow updating translations
 }
+
+// TopicTransitionRequest moves a topic to another editorial state.
+type TopicTransitionRequest struct {
+	To    entities.TopicState `json:"to" binding:"required"`
+	Notes string              `json:"notes,omitempty"` // Required when sending a change back to draft
+}
+
+// TopicFieldChange is one field that differs between two versions of a topic. Fields are named
+// by their JSON path, e.g. "translations.en.self_care"; list fields also report the added and
+// removed items.
+type TopicFieldChange struct {
+	Field   string      `json:"field"`
+	From    interface{} `json:"from,omitempty"`
+	To      interface{} `json:"to,omitempty"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+}
+
+// TopicDiff lists the changes between two versions of a topic.
+type TopicDiff struct {
+	TopicKey string             `json:"topic_key"`
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	Changes  []TopicFieldChange `json:"changes"`
+}
+
+// TopicRollbackRequest explains a rollback.
+type TopicRollbackRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// TopicServedAt tells which version of a topic users were served at a point in time.
+type TopicServedAt struct {
+	TopicKey string    `json:"topic_key"`
+	At       time.Time `json:"at"`
+	// Served is false when the topic was not published yet or was archived at that time
+	Served   bool                    `json:"served"`
+	Revision *entities.TopicRevision `json:"revision,omitempty"`
+}
+
+// TopicRecord is one topic in an export or import file: its content and the published version
+// it was exported from. State is informational and ignored on import.
+type TopicRecord struct {
+	TopicKey string              `json:"topic_key"`
+	Version  int                 `json:"version,omitempty"`
+	State    entities.TopicState `json:"state,omitempty"`
+	entities.TopicContent
+}
+
+// TopicExportQuery selects the topics and languages to export; empty fields select everything.
+type TopicExportQuery struct {
+	State     string
+	TopicKeys []string
+	Languages []string
+}
+
+// TopicImportAction is what an import does, or would do, with one row.
+type TopicImportAction string
+
+const (
+	TopicImportCreated   TopicImportAction = "created"
+	TopicImportUpdated   TopicImportAction = "updated"
+	TopicImportUnchanged TopicImportAction = "unchanged"
+	TopicImportInvalid   TopicImportAction = "invalid"
+)
+
+// TopicImportRow is the outcome of one row. Rows are numbered from 1, not counting a CSV header.
+type TopicImportRow struct {
+	Row      int               `json:"row"`
+	TopicKey string            `json:"topic_key"`
+	Action   TopicImportAction `json:"action"`
+	// Version is the published version of an existing topic; importing only saves a draft, so
+	// it stays the same until the draft is published
+	Version int                `json:"version,omitempty"`
+	Changes []TopicFieldChange `json:"changes,omitempty"`
+	Errors  []string           `json:"errors,omitempty"`
+}
+
+// TopicImportReport summarises an import. Nothing is written when it is a dry run or any row
+// is invalid.
+type TopicImportReport struct {
+	DryRun    bool             `json:"dry_run"`
+	Applied   bool             `json:"applied"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Invalid   int              `json:"invalid"`
+	Rows      []TopicImportRow `json:"rows"`
+}
+
+// TopicPublishConfig holds the checks a topic must pass before it is published.
+type TopicPublishConfig struct {
+	// RequiredLanguages must have complete translations; empty disables the check
+	RequiredLanguages []string
+}
+
+// Translation problems found in topic content
+const (
+	TranslationMissing          = "missing_translation"
+	TranslationEmptySelfCare    = "empty_self_care"
+	TranslationEmptySeekCareIf  = "empty_seek_care_if"
+	TranslationNoDisclaimer     = "missing_disclaimer"
+	TranslationOTCCountMismatch = "otc_count_mismatch"
+	TranslationWrongScript      = "wrong_script"
+)
+
+// Severities of translation problems: errors make a translation incomplete, warnings need a look
+const (
+	TranslationError   = "error"
+	TranslationWarning = "warning"
+)
+
+// TranslationIssue is one problem with a topic's content in one language.
+type TranslationIssue struct {
+	Language string `json:"language"`
+	Field    string `json:"field"` // JSON path, e.g. "translations.am.self_care"
+	Problem  string `json:"problem"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// TopicTranslationStatus lists the translation problems of one topic.
+type TopicTranslationStatus struct {
+	TopicKey string              `json:"topic_key"`
+	State    entities.TopicState `json:"state"`
+	Version  int                 `json:"version"`
+	Errors   int                 `json:"errors"`
+	Warnings int                 `json:"warnings"`
+	Issues   []TranslationIssue  `json:"issues"`
+}
+
+// TranslationReport covers every active topic in every enabled language. Topics lists only the
+// topics with problems.
+type TranslationReport struct {
+	GeneratedAt       time.Time                `json:"generated_at"`
+	Languages         []string                 `json:"languages"`
+	RequiredLanguages []string                 `json:"required_languages"`
+	TopicsChecked     int                      `json:"topics_checked"`
+	TopicsComplete    int                      `json:"topics_complete"`
+	Problems          map[string]int           `json:"problems"`
+	Topics            []TopicTranslationStatus `json:"topics"`
+}
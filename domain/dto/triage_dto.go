@@ -5,7 +5,7 @@ import "remedymate-backend/domain/entities"
 // ChatRequest represents a complete chat request (combines triage, mapping, and composition)
 type ChatRequest struct {
 	Text     string `json:"text" binding:"required" validate:"min=3,max=500"`
-	Language string `json:"language" binding:"required" validate:"oneof=en am"`
+	Language string `json:"language" binding:"required,language"`
 }
 
 // ErrorResponse represents an error response
@@ -18,7 +18,7 @@ type ErrorResponse struct {
 // ComposeRequest represents the request for guidance composition
 type ComposeRequest struct {
 	TopicKey string `json:"topic_key" binding:"required"`
-	Language string `json:"language" binding:"required" validate:"oneof=en am"`
+	Language string `json:"language" binding:"required,language"`
 }
 
 // ComposeResponse represents the response from guidance composition
@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -8,10 +11,13 @@ import (
 
 // RedFlagRule represents a rule for detecting red flag symptoms
 type RedFlagRule struct {
+	ID          string      `json:"id,omitempty" bson:"id,omitempty"`
 	Keywords    []string    `json:"keywords" bson:"keywords"`
 	Language    string      `json:"language" bson:"language"`
 	Level       TriageLevel `json:"level" bson:"level"`
 	Description string      `json:"description" bson:"description"`
+	// Conditions limit the rule to matching patients, e.g. fever only escalates for infants
+	Conditions *RuleConditions `json:"conditions,omitempty" bson:"conditions,omitempty"`
 }
 
 // GuidanceCard represents the final guidance card shown to users
@@ -41,10 +47,47 @@ type ContentTranslation struct {
 
 // ApprovedBlock represents a topic with its content in multiple languages
 type ApprovedBlock struct {
-	TopicKey     string                        `json:"topic_key" bson:"topic_key"`
+	TopicKey string `json:"topic_key" bson:"topic_key"`
+	// Names and Descriptions are keyed by language code. In approved_block.json they are the
+	// name_<code> and description_<code> fields, so a new language needs only new fields.
+	Names        map[string]string `json:"-" bson:"names,omitempty"`
+	Descriptions map[string]string `json:"-" bson:"descriptions,omitempty"`
+	// Synonyms are everyday ways of describing the topic per language, used by the local topic mapper
+	Synonyms     map[string][]string           `json:"synonyms,omitempty" bson:"synonyms,omitempty"`
 	Translations map[string]ContentTranslation `json:"translations" bson:"translations"`
 }
 
+// UnmarshalJSON reads the name_<code> and description_<code> fields into Names and Descriptions
+func (b *ApprovedBlock) UnmarshalJSON(data []byte) error {
+	type plain ApprovedBlock
+	if err := json.Unmarshal(data, (*plain)(b)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	b.Names = make(map[string]string)
+	b.Descriptions = make(map[string]string)
+	for field, raw := range fields {
+		texts, code := b.Names, strings.TrimPrefix(field, "name_")
+		if code == field {
+			texts, code = b.Descriptions, strings.TrimPrefix(field, "description_")
+		}
+		if code == field || code == "" {
+			continue
+		}
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return fmt.Errorf("%s of topic %q must be a string", field, b.TopicKey)
+		}
+		if text != "" {
+			texts[code] = text
+		}
+	}
+	return nil
+}
+
 // TranslationCategory represents each OTC category inside translations
 type TranslationCategory struct {
 	CategoryName string `bson:"category_name" json:"category_name"`
@@ -59,11 +102,8 @@ type LanguageTranslation struct {
 	Disclaimer    string                `bson:"disclaimer" json:"disclaimer"`
 }
 
-// Translations holds both English and Amharic translations
-type Translations struct {
-	En LanguageTranslation `bson:"en" json:"en"`
-	Am LanguageTranslation `bson:"am" json:"am"`
-}
+// Translations holds the translation for each language, keyed by language code
+type Translations map[string]LanguageTranslation
 
 // HeadacheEntity (or HealthTopic) represents the full MongoDB document
 type HealthTopic struct {
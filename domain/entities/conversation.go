@@ -19,6 +19,7 @@ type Conversation struct {
 	UserID      string             `json:"user_id,omitempty" bson:"user_id,omitempty"` // Optional, for unauthenticated users
 	Symptom     string             `json:"symptom" bson:"symptom"`
 	Language    string             `json:"language" bson:"language"`
+	Patient     *PatientContext    `json:"patient,omitempty" bson:"patient,omitempty"` // Optional context used by triage
 	Status      ConversationStatus `json:"status" bson:"status"`
 	Questions   []Question         `json:"questions" bson:"questions"`
 	Answers     []Answer           `json:"answers" bson:"answers"`
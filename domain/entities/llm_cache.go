@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// LLMCacheEntry is a cached LLM reply keyed by a hash of the normalized request
+type LLMCacheEntry struct {
+	Key          string    `json:"key" bson:"_id"`
+	Scope        string    `json:"scope" bson:"scope"`
+	Version      string    `json:"version,omitempty" bson:"version"`
+	Model        string    `json:"model" bson:"model"`
+	Provider     string    `json:"provider,omitempty" bson:"provider,omitempty"`
+	Text         string    `json:"text" bson:"text"`
+	FinishReason string    `json:"finishReason,omitempty" bson:"finishReason,omitempty"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+	ExpiresAt    time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// Expired reports whether the entry is past its TTL at now
+func (e *LLMCacheEntry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
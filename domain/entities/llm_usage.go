@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// LLMUsageRecord is one provider call, kept for usage and cost accounting
+type LLMUsageRecord struct {
+	ID               string    `json:"id" bson:"_id,omitempty"`
+	Feature          string    `json:"feature" bson:"feature"`
+	PromptVersion    string    `json:"promptVersion,omitempty" bson:"promptVersion,omitempty"`
+	Provider         string    `json:"provider" bson:"provider"`
+	Model            string    `json:"model" bson:"model"`
+	PromptTokens     int       `json:"promptTokens" bson:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens" bson:"completionTokens"`
+	TotalTokens      int       `json:"totalTokens" bson:"totalTokens"`
+	LatencyMs        int64     `json:"latencyMs" bson:"latencyMs"`
+	Success          bool      `json:"success" bson:"success"`
+	Error            string    `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt        time.Time `json:"createdAt" bson:"createdAt"`
+}
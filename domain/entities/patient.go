@@ -0,0 +1,111 @@
+package entities
+
+import "strings"
+
+// AgeBand groups patients by age; triage rules target bands rather than exact ages
+type AgeBand string
+
+const (
+	AgeBandInfant     AgeBand = "infant"      // under 1 year
+	AgeBandChild      AgeBand = "child"       // 1-12 years
+	AgeBandAdolescent AgeBand = "adolescent"  // 13-17 years
+	AgeBandAdult      AgeBand = "adult"       // 18-64 years
+	AgeBandOlderAdult AgeBand = "older_adult" // 65 years and over
+)
+
+// PregnancyStatus is what the user told us about pregnancy
+type PregnancyStatus string
+
+const (
+	PregnancyStatusPregnant    PregnancyStatus = "pregnant"
+	PregnancyStatusNotPregnant PregnancyStatus = "not_pregnant"
+	PregnancyStatusUnknown     PregnancyStatus = "unknown"
+)
+
+// PatientContext is optional structured information about the patient that
+// triage rules can use to escalate, e.g. fever in an infant
+type PatientContext struct {
+	AgeBand   AgeBand         `json:"age_band,omitempty" bson:"age_band,omitempty"`
+	Pregnancy PregnancyStatus `json:"pregnancy,omitempty" bson:"pregnancy,omitempty"`
+	// ChronicConditions are short lowercase names such as "diabetes" or "hypertension"
+	ChronicConditions []string `json:"chronic_conditions,omitempty" bson:"chronic_conditions,omitempty"`
+}
+
+// IsEmpty reports whether no context was given
+func (p *PatientContext) IsEmpty() bool {
+	return p == nil || (p.AgeBand == "" && p.Pregnancy == "" && len(p.ChronicConditions) == 0)
+}
+
+// HasCondition reports whether the patient has the chronic condition (case-insensitive)
+func (p *PatientContext) HasCondition(condition string) bool {
+	if p == nil {
+		return false
+	}
+	condition = NormalizeCondition(condition)
+	for _, c := range p.ChronicConditions {
+		if NormalizeCondition(c) == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeCondition lowercases a chronic condition name and joins words with underscores
+func NormalizeCondition(condition string) string {
+	return strings.Join(strings.Fields(strings.ToLower(condition)), "_")
+}
+
+// RuleConditions restrict a triage rule to patients matching the context.
+// Every non-empty field must match; a rule without conditions applies to everyone.
+// A conditional rule never fires for a patient whose context is unknown.
+type RuleConditions struct {
+	// AgeBands matches when the patient is in any of the bands
+	AgeBands []AgeBand `json:"ageBands,omitempty" bson:"ageBands,omitempty"`
+	// Pregnant matches only patients reported as pregnant
+	Pregnant bool `json:"pregnant,omitempty" bson:"pregnant,omitempty"`
+	// ChronicConditions matches when the patient has any of the conditions
+	ChronicConditions []string `json:"chronicConditions,omitempty" bson:"chronicConditions,omitempty"`
+}
+
+// IsEmpty reports whether the conditions place no restriction
+func (c *RuleConditions) IsEmpty() bool {
+	return c == nil || (len(c.AgeBands) == 0 && !c.Pregnant && len(c.ChronicConditions) == 0)
+}
+
+// Matches reports whether the patient satisfies every condition
+func (c *RuleConditions) Matches(p *PatientContext) bool {
+	if c.IsEmpty() {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+	if len(c.AgeBands) > 0 {
+		found := false
+		for _, band := range c.AgeBands {
+			if band == p.AgeBand {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if c.Pregnant && p.Pregnancy != PregnancyStatusPregnant {
+		return false
+	}
+	if len(c.ChronicConditions) > 0 {
+		found := false
+		for _, condition := range c.ChronicConditions {
+			if p.HasCondition(condition) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
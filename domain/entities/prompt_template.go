@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Prompt template sources
+const (
+	PromptSourceFile  = "file"  // seeded from data/prompts
+	PromptSourceAdmin = "admin" // created through the admin API
+)
+
+// AdminPromptVersionBase is the first version number given to versions created through the
+// admin API. Files in data/prompts are numbered below it, so a version such as triage@v2 is
+// the same text in the evaluation harness and in production.
+const AdminPromptVersionBase = 1000
+
+// PromptTemplate is one version of a named LLM prompt. The body is a text/template
+// defining a "user" block and, optionally, a "system" block. One version per name is active.
+type PromptTemplate struct {
+	ID          string     `bson:"_id" json:"id"`
+	Name        string     `bson:"name" json:"name"`
+	Version     int        `bson:"version" json:"version"`
+	Body        string     `bson:"body" json:"body"`
+	Description string     `bson:"description,omitempty" json:"description,omitempty"`
+	Active      bool       `bson:"active" json:"active"`
+	Source      string     `bson:"source" json:"source"`
+	CreatedBy   string     `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt   time.Time  `bson:"createdAt" json:"createdAt"`
+	ActivatedBy string     `bson:"activatedBy,omitempty" json:"activatedBy,omitempty"`
+	ActivatedAt *time.Time `bson:"activatedAt,omitempty" json:"activatedAt,omitempty"`
+}
+
+// PromptVersionRef identifies a template version in logs, e.g. "triage@v2"
+func PromptVersionRef(name string, version int) string {
+	return fmt.Sprintf("%s@v%d", name, version)
+}
@@ -3,16 +3,31 @@ package entities
 import "time"
 
 type RedFlag struct {
-	ID          string       `bson:"_id,omitempty" json:"id"`
-	Keywords    []string     `bson:"keywords" json:"keywords"`
-	Language    string       `bson:"language" json:"language"`
-	Level       TriageLevel  `bson:"level" json:"level"`
-	Description string       `bson:"description" json:"description"`
-	IsDeleted   bool         `bson:"isDeleted" json:"-"`
-	CreatedAt   time.Time    `bson:"createdAt" json:"createdAt"`
-	UpdatedAt   time.Time    `bson:"updatedAt" json:"updatedAt"`
-	DeletedAt   *time.Time   `bson:"deletedAt,omitempty" json:"-"`
-	CreatedBy   *string      `bson:"createdBy,omitempty" json:"-"`
-	UpdatedBy   *string      `bson:"updatedBy,omitempty" json:"-"`
-	DeletedBy   *string      `bson:"deletedBy,omitempty" json:"-"`
+	ID          string          `bson:"_id,omitempty" json:"id"`
+	Keywords    []string        `bson:"keywords" json:"keywords"`
+	Language    string          `bson:"language" json:"language"`
+	Level       TriageLevel     `bson:"level" json:"level"`
+	Description string          `bson:"description" json:"description"`
+	Conditions  *RuleConditions `bson:"conditions" json:"conditions,omitempty"`
+	// SeedID is the "id" of the bundled rule this one was seeded from, so later releases add only new rules
+	SeedID    string     `bson:"seedId,omitempty" json:"-"`
+	IsDeleted bool       `bson:"isDeleted" json:"-"`
+	CreatedAt time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time  `bson:"updatedAt" json:"updatedAt"`
+	DeletedAt *time.Time `bson:"deletedAt,omitempty" json:"-"`
+	CreatedBy *string    `bson:"createdBy,omitempty" json:"-"`
+	UpdatedBy *string    `bson:"updatedBy,omitempty" json:"-"`
+	DeletedBy *string    `bson:"deletedBy,omitempty" json:"-"`
+}
+
+// Rule converts the admin-managed red flag into the rule shape used by triage
+func (rf RedFlag) Rule() RedFlagRule {
+	return RedFlagRule{
+		ID:          rf.ID,
+		Keywords:    rf.Keywords,
+		Language:    rf.Language,
+		Level:       rf.Level,
+		Description: rf.Description,
+		Conditions:  rf.Conditions,
+	}
 }
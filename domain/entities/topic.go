@@ -15,6 +15,69 @@ const (
 	TopicStatusDeleted TopicStatus = "deleted"
 )
 
+// TopicState is the editorial state of a topic. Changes are written to a draft, reviewed by
+// someone other than their authors, approved and then published; only published content is
+// served to users, and an archived topic is not served at all.
+type TopicState string
+
+const (
+	TopicStateDraft     TopicState = "draft"
+	TopicStateInReview  TopicState = "in_review"
+	TopicStateApproved  TopicState = "approved"
+	TopicStatePublished TopicState = "published"
+	TopicStateArchived  TopicState = "archived"
+)
+
+// TopicContent is the editable content of a topic.
+type TopicContent struct {
+	NameEN        string                              `json:"name_en" bson:"name_en"`
+	NameAM        string                              `json:"name_am" bson:"name_am"`
+	DescriptionEN string                              `json:"description_en,omitempty" bson:"description_en,omitempty"`
+	DescriptionAM string                              `json:"description_am,omitempty" bson:"description_am,omitempty"`
+	Synonyms      map[string][]string                 `json:"synonyms,omitempty" bson:"synonyms,omitempty"`
+	Translations  map[string]LocalizedGuidanceContent `json:"translations" bson:"translations"`
+}
+
+// Names returns the topic name per language code, leaving out languages without one
+func (c TopicContent) Names() map[string]string {
+	return presentTexts(map[string]string{"en": c.NameEN, "am": c.NameAM})
+}
+
+// Descriptions returns the topic description per language code, leaving out languages without one
+func (c TopicContent) Descriptions() map[string]string {
+	return presentTexts(map[string]string{"en": c.DescriptionEN, "am": c.DescriptionAM})
+}
+
+func presentTexts(texts map[string]string) map[string]string {
+	for code, text := range texts {
+		if text == "" {
+			delete(texts, code)
+		}
+	}
+	return texts
+}
+
+// TopicDraft is a pending change to a topic, kept alongside the published content until it is published.
+type TopicDraft struct {
+	TopicContent `bson:",inline"`
+	// Authors are everyone who edited the draft; none of them may approve it
+	Authors   []primitive.ObjectID `json:"authors" bson:"authors"`
+	CreatedAt time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at" bson:"updated_at"`
+
+	// RestoredFrom is the version a rollback copied into the draft; editing the draft clears it
+	RestoredFrom int `json:"restored_from,omitempty" bson:"restored_from,omitempty"`
+}
+
+// TopicTransition records an editorial state change with who made it and when.
+type TopicTransition struct {
+	From  TopicState         `json:"from,omitempty" bson:"from,omitempty"`
+	To    TopicState         `json:"to" bson:"to"`
+	Actor primitive.ObjectID `json:"actor,omitempty" bson:"actor,omitempty"`
+	At    time.Time          `json:"at" bson:"at"`
+	Notes string             `json:"notes,omitempty" bson:"notes,omitempty"`
+}
+
 // LocalizedGuidanceContent holds the structured guidance for one language.
 type LocalizedGuidanceContent struct {
 	SelfCare      []string      `json:"self_care" bson:"self_care"`
@@ -33,18 +96,75 @@ type RevisionEntry struct {
 
 // Topic is the MongoDB document for a RemedyMate topic / approved block.
 type Topic struct {
-	ID            primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
-	TopicKey      string             `json:"topic_key" bson:"topic_key"` // unique human-friendly key
-	NameEN        string             `json:"name_en" bson:"name_en"`
-	NameAM        string             `json:"name_am" bson:"name_am"`
-	DescriptionEN string             `json:"description_en,omitempty" bson:"description_en,omitempty"`
-	DescriptionAM string             `json:"description_am,omitempty" bson:"description_am,omitempty"`
-	Status        TopicStatus        `json:"status" bson:"status"` // active | deleted
-	Translations    map[string]LocalizedGuidanceContent `json:"translations" bson:"translations"` // expect at least "en" and "am"
-	Version         int                                 `json:"version" bson:"version"`           // increment for major changes
+	ID              primitive.ObjectID                  `json:"id,omitempty" bson:"_id,omitempty"`
+	TopicKey        string                              `json:"topic_key" bson:"topic_key"` // unique human-friendly key
+	NameEN          string                              `json:"name_en" bson:"name_en"`
+	NameAM          string                              `json:"name_am" bson:"name_am"`
+	DescriptionEN   string                              `json:"description_en,omitempty" bson:"description_en,omitempty"`
+	DescriptionAM   string                              `json:"description_am,omitempty" bson:"description_am,omitempty"`
+	Synonyms        map[string][]string                 `json:"synonyms,omitempty" bson:"synonyms,omitempty"` // everyday phrasings per language, used for topic mapping
+	Status          TopicStatus                         `json:"status" bson:"status"`                         // active | deleted
+	State           TopicState                          `json:"state" bson:"state"`                           // editorial state; topics saved before the workflow have none and count as published
+	Draft           *TopicDraft                         `json:"draft,omitempty" bson:"draft,omitempty"`
+	Transitions     []TopicTransition                   `json:"transitions,omitempty" bson:"transitions,omitempty"`
+	PublishedAt     *time.Time                          `json:"published_at,omitempty" bson:"published_at,omitempty"`
+	PublishedBy     primitive.ObjectID                  `json:"published_by,omitempty" bson:"published_by,omitempty"`
+	Translations    map[string]LocalizedGuidanceContent `json:"translations" bson:"translations"` // keyed by language code from the language registry
+	Version         int                                 `json:"version" bson:"version"`           // published version, incremented on each publication
 	RevisionHistory []RevisionEntry                     `json:"revision_history,omitempty" bson:"revision_history,omitempty"`
 	CreatedAt       time.Time                           `json:"created_at" bson:"created_at"`
 	UpdatedAt       time.Time                           `json:"updated_at" bson:"updated_at"`
 	CreatedBy       primitive.ObjectID                  `json:"created_by,omitempty" bson:"created_by,omitempty"`
 	UpdatedBy       primitive.ObjectID                  `json:"updated_by,omitempty" bson:"updated_by,omitempty"`
 }
+
+// EditorialState returns the topic's state, treating topics saved before the workflow as published
+func (t Topic) EditorialState() TopicState {
+	if t.State == "" {
+		return TopicStatePublished
+	}
+	return t.State
+}
+
+// Content returns the published content of the topic.
+func (t Topic) Content() TopicContent {
+	return TopicContent{
+		NameEN:        t.NameEN,
+		NameAM:        t.NameAM,
+		DescriptionEN: t.DescriptionEN,
+		DescriptionAM: t.DescriptionAM,
+		Synonyms:      t.Synonyms,
+		Translations:  t.Translations,
+	}
+}
+
+// SetContent replaces the published content of the topic.
+func (t *Topic) SetContent(content TopicContent) {
+	t.NameEN = content.NameEN
+	t.NameAM = content.NameAM
+	t.DescriptionEN = content.DescriptionEN
+	t.DescriptionAM = content.DescriptionAM
+	t.Synonyms = content.Synonyms
+	t.Translations = content.Translations
+}
+
+// ApprovedBlock converts the topic to the content block served to users
+func (t Topic) ApprovedBlock() ApprovedBlock {
+	translations := make(map[string]ContentTranslation, len(t.Translations))
+	for code, content := range t.Translations {
+		translations[code] = ContentTranslation{
+			SelfCare:      content.SelfCare,
+			OTCCategories: content.OTCCategories,
+			SeekCareIf:    content.SeekCareIf,
+			Disclaimer:    content.Disclaimer,
+		}
+	}
+	content := t.Content()
+	return ApprovedBlock{
+		TopicKey:     t.TopicKey,
+		Names:        content.Names(),
+		Descriptions: content.Descriptions(),
+		Synonyms:     t.Synonyms,
+		Translations: translations,
+	}
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TopicRevision is an immutable snapshot of a topic's content as published under one version.
+// Together with the topic's transitions it tells what users were served on a given date.
+type TopicRevision struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	TopicKey    string             `json:"topic_key" bson:"topic_key"`
+	Version     int                `json:"version" bson:"version"`
+	Content     TopicContent       `json:"content" bson:"content"`
+	PublishedAt time.Time          `json:"published_at" bson:"published_at"`
+	PublishedBy primitive.ObjectID `json:"published_by,omitempty" bson:"published_by,omitempty"`
+	Notes       string             `json:"notes,omitempty" bson:"notes,omitempty"`
+	// RestoredFrom is the version whose content a rollback republished
+	RestoredFrom int `json:"restored_from,omitempty" bson:"restored_from,omitempty"`
+}
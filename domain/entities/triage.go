@@ -9,15 +9,62 @@ const (
 	TriageLevelRed    TriageLevel = "RED"    // Seek urgent care now
 )
 
+// Severity ranks the level so results can be compared; unknown levels rank lowest
+func (l TriageLevel) Severity() int {
+	switch l {
+	case TriageLevelRed:
+		return 3
+	case TriageLevelYellow:
+		return 2
+	case TriageLevelGreen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// MoreSevere returns whichever of the two levels is more severe
+func MoreSevere(a, b TriageLevel) TriageLevel {
+	if b.Severity() > a.Severity() {
+		return b
+	}
+	return a
+}
+
 // TriageResult represents the result of symptom triage
 type TriageResult struct {
 	Level    TriageLevel `json:"level" bson:"level"`
 	RedFlags []string    `json:"red_flags" bson:"red_flags"`
 	Message  string      `json:"message" bson:"message"`
+
+	// MatchedRules lists the concrete rules that fired and the words that triggered them
+	MatchedRules []RuleMatch `json:"matched_rules,omitempty" bson:"matched_rules,omitempty"`
+
+	// Agreement is the share of LLM samples that voted for the final level (0 when the LLM was not used)
+	Agreement float64 `json:"agreement,omitempty" bson:"agreement,omitempty"`
+	// LLMCalls is how many LLM requests the decision cost
+	LLMCalls int `json:"llm_calls,omitempty" bson:"llm_calls,omitempty"`
+
+	// Trace holds the decision details for the audit trail; it is never sent to clients
+	Trace *TriageTrace `json:"-" bson:"-"`
+}
+
+// RuleMatch explains why a red/yellow flag rule fired for a triage result
+type RuleMatch struct {
+	RuleID      string      `json:"rule_id" bson:"rule_id"`
+	Description string      `json:"description" bson:"description"`
+	Level       TriageLevel `json:"level" bson:"level"`
+	// Evidence is the user's own text that matched; empty when the LLM cited
+	// the rule without a quote we could find in the input
+	Evidence string `json:"evidence" bson:"evidence"`
+	// Start and End are rune offsets of Evidence in the input text, -1 when not located
+	Start  int          `json:"start" bson:"start"`
+	End    int          `json:"end" bson:"end"`
+	Source TriageSource `json:"source" bson:"source"` // keyword or llm
 }
 
 // SymptomInput represents user input for symptoms
 type SymptomInput struct {
 	Text     string `json:"text" bson:"text"`
-	Language string `json:"language" bson:"language"` // "en" or "am"
+	Language string `json:"language" bson:"language"` // language registry code, e.g. "en"
 }
@@ -0,0 +1,92 @@
+package entities
+
+import "time"
+
+// TriageSource records which part of the triage pipeline decided the level
+type TriageSource string
+
+const (
+	TriageSourceKeyword         TriageSource = "keyword"          // red flag keyword pre-screen, LLM not called
+	TriageSourceLLM             TriageSource = "llm"              // LLM classification (raised by keyword floor if any)
+	TriageSourceKeywordFallback TriageSource = "keyword_fallback" // LLM failed, keyword floor returned
+)
+
+// TriageTrace carries how a triage result was reached
+type TriageTrace struct {
+	Source        TriageSource
+	Rules         TriageRuleSnapshot
+	PromptHash    string
+	PromptVersion string
+	RawResponse   string
+	LLMProvider   string
+	LLMError      string
+	LatencyMs     int64
+	LLMCalls      int
+	Agreement     float64
+	Samples       []TriageSample
+	// InjectionPatterns names the prompt-injection heuristics the input matched
+	InjectionPatterns []string
+}
+
+// TriageSample is one LLM vote when triage runs in consensus mode
+type TriageSample struct {
+	Level       string   `bson:"level,omitempty" json:"level,omitempty"` // RED, YELLOW, GREEN or UNCLEAR
+	Temperature *float32 `bson:"temperature,omitempty" json:"temperature,omitempty"`
+	RawResponse string   `bson:"rawResponse,omitempty" json:"rawResponse,omitempty"`
+	Provider    string   `bson:"provider,omitempty" json:"provider,omitempty"`
+	Error       string   `bson:"error,omitempty" json:"error,omitempty"`
+	LatencyMs   int64    `bson:"latencyMs" json:"latencyMs"`
+}
+
+// TriageRuleSnapshot is the exact red/yellow rule set used for a triage decision
+type TriageRuleSnapshot struct {
+	Version     string        `bson:"_id" json:"version"`
+	RedRules    []RedFlagRule `bson:"redRules" json:"redRules"`
+	YellowRules []RedFlagRule `bson:"yellowRules" json:"yellowRules"`
+	CreatedAt   time.Time     `bson:"createdAt" json:"createdAt"`
+}
+
+// ForPatient returns the rules that apply to the patient; rules whose conditions
+// the patient does not meet are left out. The version is kept for auditing.
+func (s TriageRuleSnapshot) ForPatient(p *PatientContext) TriageRuleSnapshot {
+	applicable := s
+	applicable.RedRules = rulesForPatient(s.RedRules, p)
+	applicable.YellowRules = rulesForPatient(s.YellowRules, p)
+	return applicable
+}
+
+func rulesForPatient(rules []RedFlagRule, p *PatientContext) []RedFlagRule {
+	out := make([]RedFlagRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Conditions.Matches(p) {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
+
+// TriageAudit is the persisted record of a single triage outcome
+type TriageAudit struct {
+	ID                string          `bson:"_id,omitempty" json:"id"`
+	SessionID         string          `bson:"sessionId,omitempty" json:"sessionId,omitempty"`
+	InputText         string          `bson:"inputText" json:"inputText"`
+	Language          string          `bson:"language" json:"language"`
+	Patient           *PatientContext `bson:"patientContext,omitempty" json:"patientContext,omitempty"`
+	Level             TriageLevel     `bson:"level" json:"level"`
+	Flags             []string        `bson:"flags" json:"flags"`
+	MatchedRules      []RuleMatch     `bson:"matchedRules,omitempty" json:"matchedRules,omitempty"`
+	Message           string          `bson:"message" json:"message"`
+	Source            TriageSource    `bson:"source" json:"source"`
+	RulesVersion      string          `bson:"rulesVersion" json:"rulesVersion"`
+	PromptHash        string          `bson:"promptHash,omitempty" json:"promptHash,omitempty"`
+	PromptVersion     string          `bson:"promptVersion,omitempty" json:"promptVersion,omitempty"`
+	RawResponse       string          `bson:"rawResponse,omitempty" json:"rawResponse,omitempty"`
+	LLMProvider       string          `bson:"llmProvider,omitempty" json:"llmProvider,omitempty"`
+	LLMError          string          `bson:"llmError,omitempty" json:"llmError,omitempty"`
+	LatencyMs         int64           `bson:"latencyMs" json:"latencyMs"`
+	LLMCalls          int             `bson:"llmCalls" json:"llmCalls"`
+	Agreement         float64         `bson:"agreement,omitempty" json:"agreement,omitempty"`
+	Samples           []TriageSample  `bson:"samples,omitempty" json:"samples,omitempty"`
+	InjectionPatterns []string        `bson:"injectionPatterns,omitempty" json:"injectionPatterns,omitempty"`
+	CreatedAt         time.Time       `bson:"createdAt" json:"createdAt"`
+}
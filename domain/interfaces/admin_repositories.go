@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"remedymate-backend/domain/dto"
 	"remedymate-backend/domain/entities"
 )
 
@@ -11,6 +12,7 @@ type RedFlagRepository interface {
 	Create(ctx context.Context, rf *entities.RedFlag) error
 	Update(ctx context.Context, rf *entities.RedFlag) error
 	SoftDelete(ctx context.Context, id string, deletedBy string) error
+	SeedRule(ctx context.Context, rf *entities.RedFlag) (bool, error)
 }
 
 type FeedbackRepository interface {
@@ -21,6 +23,17 @@ type FeedbackRepository interface {
 	Create(ctx context.Context, f *entities.Feedback) error
 }
 
+type TriageAuditRepository interface {
+	Create(ctx context.Context, audit *entities.TriageAudit) error
+	GetByID(ctx context.Context, id string) (*entities.TriageAudit, error)
+	Search(ctx context.Context, filter dto.TriageAuditFilter) ([]entities.TriageAudit, int64, error)
+	// RuleStats counts matched rules across the audits selected by filter (Limit/Offset are ignored)
+	RuleStats(ctx context.Context, filter dto.TriageAuditFilter) ([]dto.TriageRuleStat, error)
+	// SaveRuleSnapshot stores the rule set for a version once; later calls for the same version are no-ops
+	SaveRuleSnapshot(ctx context.Context, snapshot *entities.TriageRuleSnapshot) error
+	GetRuleSnapshot(ctx context.Context, version string) (*entities.TriageRuleSnapshot, error)
+}
+
 type AnalyticsRepository interface {
 	InsertEvent(ctx context.Context, evt map[string]interface{}) error
 	UsageCounts(ctx context.Context, from, to string) (interface{}, error)
@@ -20,6 +20,27 @@ type AdminFeedbackUsecase interface {
 	Delete(ctx context.Context, id string) error
 }
 
+type AdminTriageAuditUsecase interface {
+	Search(ctx context.Context, filter dto.TriageAuditFilter) ([]entities.TriageAudit, int64, error)
+	Get(ctx context.Context, id string) (*entities.TriageAudit, error)
+	GetRuleSnapshot(ctx context.Context, version string) (*entities.TriageRuleSnapshot, error)
+	RuleStats(ctx context.Context, filter dto.TriageAuditFilter) ([]dto.TriageRuleStat, error)
+}
+
 type AdminAnalyticsUsecase interface {
 	Get(ctx context.Context, from, to string) (map[string]interface{}, error)
 }
+
+type AdminLLMUsecase interface {
+	CacheStats(ctx context.Context) (dto.LLMCacheStats, error)
+	Usage(ctx context.Context, filter dto.LLMUsageFilter) (*dto.LLMUsageReport, error)
+	Providers(ctx context.Context) ([]dto.LLMProviderStatus, error)
+}
+
+type AdminPromptUsecase interface {
+	List(ctx context.Context) ([]dto.PromptTemplateSummary, error)
+	Versions(ctx context.Context, name string) ([]entities.PromptTemplate, error)
+	Get(ctx context.Context, name string, version int) (*entities.PromptTemplate, error)
+	Create(ctx context.Context, name string, in dto.CreatePromptVersionDTO, actor string) (*entities.PromptTemplate, error)
+	Activate(ctx context.Context, name string, version int, actor string) (*entities.PromptTemplate, error)
+}
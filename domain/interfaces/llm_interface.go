@@ -2,9 +2,71 @@ package interfaces
 
 import (
 	"context"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
 )
 
-// LLMClient defines the interface for LLM interactions
+// LLMClient defines the interface for LLM interactions. Implementations adapt
+// the provider-neutral request to a specific provider (Gemini, OpenAI-compatible, Ollama).
 type LLMClient interface {
-	ClassifyTriage(ctx context.Context, prompt string) (string, error)
+	Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error)
+}
+
+// LLMCacheStore persists cached LLM replies. Get reports false for missing or expired keys.
+type LLMCacheStore interface {
+	Get(ctx context.Context, key string) (*entities.LLMCacheEntry, bool, error)
+	Set(ctx context.Context, entry *entities.LLMCacheEntry) error
+	// DeleteScope removes a scope's entries cached under any version other than keepVersion
+	DeleteScope(ctx context.Context, scope, keepVersion string) (int64, error)
+	Count(ctx context.Context) (int64, error)
+}
+
+// LLMCacheStatsProvider reports response cache hit/miss counts
+type LLMCacheStatsProvider interface {
+	CacheStats(ctx context.Context) (dto.LLMCacheStats, error)
+}
+
+// LLMUsageRepository stores per-call token usage
+type LLMUsageRepository interface {
+	Create(ctx context.Context, record *entities.LLMUsageRecord) error
+	DailyAggregates(ctx context.Context, filter dto.LLMUsageFilter) ([]dto.LLMUsageDaily, error)
+	TotalTokensSince(ctx context.Context, since time.Time) (int64, error)
+}
+
+// LLMBudgetProvider reports today's token use against the daily budget
+type LLMBudgetProvider interface {
+	BudgetStatus() dto.LLMBudgetStatus
+}
+
+// LLMProviderStatusProvider reports the health of each provider in the fallback chain
+type LLMProviderStatusProvider interface {
+	ProviderStatus() []dto.LLMProviderStatus
+}
+
+// PromptRenderer renders the active version of a named prompt template
+type PromptRenderer interface {
+	Render(name string, data map[string]string) (*dto.RenderedPrompt, error)
+}
+
+// PromptRegistry holds the active prompt templates and reloads them when versions change
+type PromptRegistry interface {
+	PromptRenderer
+	Names() []string
+	Fields(name string) []string
+	ActiveVersion(name string) int
+	// Validate reports AppError.ErrInvalidPromptTemplate for a body that does not parse or render
+	Validate(name, body string) error
+	Refresh(ctx context.Context) error
+}
+
+// PromptTemplateRepository stores prompt template versions
+type PromptTemplateRepository interface {
+	// List returns the versions of a prompt, or of all prompts when name is empty, newest first
+	List(ctx context.Context, name string) ([]entities.PromptTemplate, error)
+	Get(ctx context.Context, name string, version int) (*entities.PromptTemplate, error)
+	Create(ctx context.Context, t *entities.PromptTemplate) error
+	// Activate makes a version the active one for its prompt and deactivates the others
+	Activate(ctx context.Context, name string, version int, actor string) error
 }
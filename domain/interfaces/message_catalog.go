@@ -0,0 +1,11 @@
+package interfaces
+
+// MessageCatalog returns user-facing text by key in the requested language.
+// Missing translations fall back through the language registry; a key missing
+// everywhere is returned as-is so the gap is visible.
+type MessageCatalog interface {
+	// Message formats the text for key, replacing {name} placeholders from params
+	Message(lang, key string, params map[string]interface{}) string
+	// Plural picks the plural form of key for count; {count} is available as a placeholder
+	Plural(lang, key string, count int, params map[string]interface{}) string
+}
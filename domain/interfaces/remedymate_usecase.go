@@ -9,13 +9,15 @@ import (
 
 // TriageService defines the interface for symptom triage
 type TriageService interface {
-	ClassifySymptoms(ctx context.Context, input, lang string) (*entities.TriageResult, error)
+	// ClassifySymptoms classifies the input; patient may be nil, in which case
+	// rules with patient conditions do not apply
+	ClassifySymptoms(ctx context.Context, input, lang string, patient *entities.PatientContext) (*entities.TriageResult, error)
 	ValidateInput(inputText, lang string) error
 }
 
 // RemedyMateUsecase defines the main use case interface
 type RemedyMateUsecase interface {
-	GetTriage(ctx context.Context, input, lang string) (*dto.TriageResponse, error)
+	GetTriage(ctx context.Context, input, lang string, patient *entities.PatientContext) (*dto.TriageResponse, error)
 	MapTopic(ctx context.Context, input string) (string, error)
 	GetContent(ctx context.Context, topicKey, language string) (*entities.ContentTranslation, error)
 	// GetRemedy orchestrates the full flow and returns a consolidated RemedyResponse
@@ -27,6 +29,22 @@ type RemedyMateUsecase interface {
 type ContentService interface {
 	GetApprovedBlocks() ([]entities.ApprovedBlock, error)
 	GetContentByTopic(topicKey, language string) (*entities.ContentTranslation, error)
+	// TopicKeys lists the topics that can be served, which are the topics symptoms may be mapped to
+	TopicKeys() []string
+	// ContentVersion changes whenever the topic content is reloaded with different data
+	ContentVersion() string
+}
+
+// TriageRuleProvider supplies the red and yellow flag rules currently used by triage
+type TriageRuleProvider interface {
+	GetRedFlagRulesOnly() []entities.RedFlagRule
+	GetYellowFlagRules() []entities.RedFlagRule
+	// RulesVersion identifies the rule set so results can be tied to the rules that produced them
+	RulesVersion() string
+	// RuleSnapshot returns the red and yellow rules together with their version
+	RuleSnapshot() entities.TriageRuleSnapshot
+	// RefreshRules reloads the rules from their source, e.g. after an admin edit
+	RefreshRules(ctx context.Context) error
 }
 
 // GuidanceComposerService defines the interface for composing guidance cards
@@ -35,10 +53,13 @@ type GuidanceComposerService interface {
 	ComposeFromBlocks(ctx context.Context, topicKey, language string, blocks entities.ContentTranslation) (*entities.GuidanceCard, error)
 }
 
+// LocalTopicMapper ranks topics for a symptom description without calling the LLM
+type LocalTopicMapper interface {
+	Rank(input string, availableTopics []string) dto.TopicRanking
+}
+
+// MapTopicService maps a symptom description to one of the available topic keys
 type MapTopicService interface {
+	// MapSymptomToTopic returns the best matching key, or "" when no topic fits
 	MapSymptomToTopic(ctx context.Context, userInput string, availableTopics []string) (string, error)
-	BuildMapTopicPrompt(userInput string, availableTopics []string) string
-	CreatePayload(prompt string) map[string]any
-	ExecuteAPIRequest(ctx context.Context, body []byte) ([]byte, error)
-	ExtractTopicKeyResponse(respBody []byte) (string, error)
 }
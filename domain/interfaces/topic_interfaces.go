@@ -2,6 +2,8 @@ package interfaces
 
 import (
 	"context"
+	"time"
+
 	"remedymate-backend/domain/dto"
 	"remedymate-backend/domain/entities"
 )
@@ -13,6 +15,9 @@ type TopicRepository interface {
 	// GetTopicByKey retrieves a single topic by its unique topic_key.
 	GetTopicByKey(ctx context.Context, topicKey string) (*entities.Topic, error)
 
+	// GetTopicIncludingDeleted retrieves a topic by its topic_key, including a soft-deleted one.
+	GetTopicIncludingDeleted(ctx context.Context, topicKey string) (*entities.Topic, error)
+
 	// ListAllTopics retrieves all topics with pagination and filtering.
 	ListAllTopics(ctx context.Context, params dto.TopicListQueryParams) ([]*entities.Topic, int64, error)
 
@@ -22,10 +27,28 @@ type TopicRepository interface {
 	// DeleteTopic performs a soft delete on a topic by changing its status.
 	DeleteTopic(ctx context.Context, topicKey string, deletedByUserID string) error
 
-	// CheckTopicExists checks if a topic with the given topic_key exists.
+	// ListPublishedTopics retrieves every topic that may be served to users, without pagination.
+	ListPublishedTopics(ctx context.Context) ([]*entities.Topic, error)
+
+	// CheckTopicExists checks if a topic with the given topic_key exists, including soft-deleted ones.
 	CheckTopicExists(ctx context.Context, topicKey string) (bool, error)
 }
 
+// TopicRevisionRepository stores the immutable snapshots of published topic content.
+type TopicRevisionRepository interface {
+	// Create inserts a revision; it fails with ErrTopicRevisionExists when the version is taken.
+	Create(ctx context.Context, revision *entities.TopicRevision) error
+
+	// List returns every revision of a topic, oldest first.
+	List(ctx context.Context, topicKey string) ([]entities.TopicRevision, error)
+
+	// Get returns one version of a topic.
+	Get(ctx context.Context, topicKey string, version int) (*entities.TopicRevision, error)
+
+	// LatestAt returns the last revision published at or before the given time.
+	LatestAt(ctx context.Context, topicKey string, at time.Time) (*entities.TopicRevision, error)
+}
+
 type TopicUsecase interface {
 	// CreateTopic handles the creation of a new topic, including validation and setting audit fields.
 	CreateTopic(ctx context.Context, request dto.TopicCreateRequest) (*entities.Topic, error)
@@ -39,6 +62,39 @@ type TopicUsecase interface {
 	// UpdateTopic handles updating an existing topic, including validation and setting audit fields.
 	UpdateTopic(ctx context.Context, topicKey string, request dto.TopicUpdateRequest) (*entities.Topic, error)
 
+	// TransitionTopic moves a topic to another editorial state, recording the actor and time.
+	TransitionTopic(ctx context.Context, topicKey string, request dto.TopicTransitionRequest) (*entities.Topic, error)
+
+	// ListRevisions returns the published versions of a topic, oldest first.
+	ListRevisions(ctx context.Context, topicKey string) ([]entities.TopicRevision, error)
+
+	// GetRevision returns one published version of a topic.
+	GetRevision(ctx context.Context, topicKey string, version int) (*entities.TopicRevision, error)
+
+	// DiffRevisions compares two versions field by field; either may be "draft" for the pending change.
+	DiffRevisions(ctx context.Context, topicKey, from, to string) (*dto.TopicDiff, error)
+
+	// RollbackTopic submits the content of an earlier version for review as a new draft.
+	RollbackTopic(ctx context.Context, topicKey string, version int, notes string) (*entities.Topic, error)
+
+	// ServedAt reports which version users were served at the given time.
+	ServedAt(ctx context.Context, topicKey string, at time.Time) (*dto.TopicServedAt, error)
+
+	// ExportTopics returns the selected topics with the content being edited, ordered by key.
+	ExportTopics(ctx context.Context, query dto.TopicExportQuery) ([]dto.TopicRecord, error)
+
+	// ImportTopics upserts topics by key into their drafts; nothing is written on a dry run or when any row is invalid.
+	ImportTopics(ctx context.Context, records []dto.TopicRecord, dryRun bool) (*dto.TopicImportReport, error)
+
+	// TranslationReport checks every active topic for incomplete translations and text in the wrong script.
+	TranslationReport(ctx context.Context, languages []string) (*dto.TranslationReport, error)
+
 	// SoftDeleteTopic performs a soft delete, marking a topic as inactive but retaining its data.
 	SoftDeleteTopic(ctx context.Context, topicKey string) error
 }
+
+// TopicContentProvider serves the published topics to users
+type TopicContentProvider interface {
+	// RefreshTopics reloads the topics from their source, e.g. after an admin edit
+	RefreshTopics(ctx context.Context) error
+}
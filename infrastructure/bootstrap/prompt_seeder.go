@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/prompts"
+)
+
+// SeedPromptTemplates copies prompt template versions from the files in dir that the
+// collection does not have yet. A newly seeded version becomes active when it is newer
+// than the active file version, so prompt fixes shipped with a release go live; a prompt
+// with no active version gets its newest file. A version an admin activated, whether
+// created through the admin API or an earlier file version, stays active across restarts.
+func SeedPromptTemplates(repo interfaces.PromptTemplateRepository, dir string) error {
+	ctx := context.Background()
+
+	files, err := prompts.LoadTemplateFiles(dir)
+	if err != nil {
+		return err
+	}
+	stored, err := repo.List(ctx, "")
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]entities.PromptTemplate, len(stored))
+	active := make(map[string]entities.PromptTemplate)
+	for _, t := range stored {
+		existing[t.ID] = t
+		if t.Active {
+			active[t.Name] = t
+		}
+	}
+
+	seeded := make(map[string]bool)
+	newest := make(map[string]int)
+	for _, t := range files {
+		if t.Version > newest[t.Name] {
+			newest[t.Name] = t.Version
+		}
+		if stored, ok := existing[t.ID]; ok {
+			if stored.Source != entities.PromptSourceFile {
+				log.Printf("⚠️ Prompt %s was created by an admin; %s/%s/v%d.tmpl is not seeded", t.ID, dir, t.Name, t.Version)
+			}
+			continue
+		}
+		t.CreatedBy = "system"
+		if err := repo.Create(ctx, &t); err != nil {
+			return err
+		}
+		seeded[t.ID] = true
+	}
+	for name, version := range newest {
+		ref := entities.PromptVersionRef(name, version)
+		if current, ok := active[name]; ok && !(seeded[ref] && current.Source == entities.PromptSourceFile && current.Version < version) {
+			continue
+		}
+		if stored, ok := existing[ref]; ok && stored.Source != entities.PromptSourceFile {
+			continue
+		}
+		if err := repo.Activate(ctx, name, version, "system"); err != nil {
+			return err
+		}
+		log.Printf("✅ Activated prompt %s", ref)
+	}
+
+	if len(seeded) > 0 {
+		log.Printf("✅ Seeded %d prompt template versions from %s", len(seeded), dir)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/content"
+)
+
+// SeedRedFlags copies the JSON red/yellow flag rules into the red flag collection, so admins
+// start from the bundled rule set. Each bundled rule is added once: rules a release adds reach
+// existing deployments, while rules admins edited or deleted are left as they are.
+func SeedRedFlags(redFlagRepo interfaces.RedFlagRepository, dataPath string) error {
+	ctx := context.Background()
+
+	seeded := 0
+	for _, file := range []string{"red_flag_rules.json", "yellow_flag_rules.json"} {
+		rules, err := content.LoadRuleFile(filepath.Join(dataPath, file))
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			createdBy := "system"
+			rf := &entities.RedFlag{
+				Keywords:    rule.Keywords,
+				Language:    rule.Language,
+				Level:       rule.Level,
+				Description: rule.Description,
+				Conditions:  rule.Conditions,
+				SeedID:      rule.ID,
+				CreatedBy:   &createdBy,
+			}
+			created, err := redFlagRepo.SeedRule(ctx, rf)
+			if err != nil {
+				return err
+			}
+			if created {
+				seeded++
+			}
+		}
+	}
+
+	if seeded > 0 {
+		log.Printf("✅ Seeded %d red flag rules from %s", seeded, dataPath)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"time"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/content"
+)
+
+// SeedTopics copies the topics in data/approved_block.json into the topic collection and
+// records them as revision 1. Topics that already exist, including soft-deleted ones, are
+// left alone so admin edits and deletions survive a restart.
+func SeedTopics(topicRepo interfaces.TopicRepository, revisions interfaces.TopicRevisionRepository, dataPath string) error {
+	ctx := context.Background()
+
+	blocks, err := content.LoadBlockFile(filepath.Join(dataPath, "approved_block.json"))
+	if err != nil {
+		return err
+	}
+
+	seeded := 0
+	for _, block := range blocks {
+		exists, err := topicRepo.CheckTopicExists(ctx, block.TopicKey)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		translations := make(map[string]entities.LocalizedGuidanceContent, len(block.Translations))
+		for code, content := range block.Translations {
+			translations[code] = entities.LocalizedGuidanceContent{
+				SelfCare:      content.SelfCare,
+				OTCCategories: content.OTCCategories,
+				SeekCareIf:    content.SeekCareIf,
+				Disclaimer:    content.Disclaimer,
+			}
+		}
+		// The bundled content is already reviewed, so it is seeded as published
+		now := time.Now()
+		topic := &entities.Topic{
+			TopicKey:      block.TopicKey,
+			NameEN:        block.Names["en"],
+			NameAM:        block.Names["am"],
+			DescriptionEN: block.Descriptions["en"],
+			DescriptionAM: block.Descriptions["am"],
+			Synonyms:      block.Synonyms,
+			Status:        entities.TopicStatusActive,
+			State:         entities.TopicStatePublished,
+			Transitions:   []entities.TopicTransition{{To: entities.TopicStatePublished, At: now, Notes: "seeded from approved_block.json"}},
+			PublishedAt:   &now,
+			Translations:  translations,
+			Version:       1,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := topicRepo.CreateTopic(ctx, topic); err != nil {
+			return err
+		}
+		revision := &entities.TopicRevision{
+			TopicKey:    topic.TopicKey,
+			Version:     topic.Version,
+			Content:     topic.Content(),
+			PublishedAt: now,
+			Notes:       "seeded from approved_block.json",
+		}
+		if err := revisions.Create(ctx, revision); err != nil && !errors.Is(err, AppError.ErrTopicRevisionExists) {
+			return err
+		}
+		seeded++
+	}
+
+	if seeded > 0 {
+		log.Printf("✅ Seeded %d topics from %s", seeded, dataPath)
+	}
+	return nil
+}
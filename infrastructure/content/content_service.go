@@ -1,27 +1,47 @@
 package content
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	derrors "remedymate-backend/domain/AppError"
 	"remedymate-backend/domain/entities"
 	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/util/lang"
 )
 
 type ContentService struct {
 	approvedBlocks  []entities.ApprovedBlock
+	topicsVersion   string
 	redFlagRules    []entities.RedFlagRule
 	yellowFlagRules []entities.RedFlagRule
+	rulesVersion    string
 	dataPath        string
+
+	// redFlagRepo is the admin-managed rule source; nil means JSON rules only
+	redFlagRepo interfaces.RedFlagRepository
+	// topicRepo is the admin-managed topic source; nil means approved_block.json only
+	topicRepo interfaces.TopicRepository
+	mu        sync.RWMutex
 }
 
-// NewContentService creates a new content service instance
-func NewContentService(dataPath string) interfaces.ContentService {
+// NewContentService creates a new content service instance.
+// Topics and rules from data/*.json are loaded first as a bootstrap; when a red flag or
+// topic repository is given, the admin-managed rules or published topics replace them and
+// are kept in memory until the next refresh.
+func NewContentService(dataPath string, redFlagRepo interfaces.RedFlagRepository, topicRepo interfaces.TopicRepository) interfaces.ContentService {
 	service := &ContentService{
-		dataPath: dataPath,
+		dataPath:    dataPath,
+		redFlagRepo: redFlagRepo,
+		topicRepo:   topicRepo,
 	}
 
 	// Load content on initialization
@@ -29,48 +49,178 @@ func NewContentService(dataPath string) interfaces.ContentService {
 		fmt.Printf("❌ Failed to load content: %v\n", err)
 	}
 
+	if redFlagRepo != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := service.RefreshRules(ctx); err != nil {
+			fmt.Printf("❌ Failed to load red flag rules from database, using JSON rules: %v\n", err)
+		}
+	}
+
+	if topicRepo != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := service.RefreshTopics(ctx); err != nil {
+			fmt.Printf("❌ Failed to load topics from database, using approved_block.json: %v\n", err)
+		}
+	}
+
 	return service
 }
 
 // loads approved blocks from JSON file
 func (cs *ContentService) LoadContent() error {
 	// Load approved blocks
-	blocksPath := filepath.Join(cs.dataPath, "approved_block.json")
+	blocks, err := LoadBlockFile(filepath.Join(cs.dataPath, "approved_block.json"))
+	if err != nil {
+		return err
+	}
+	cs.setBlocks(blocks)
+
+	// Load red flag rules
+	redRules, err := LoadRuleFile(filepath.Join(cs.dataPath, "red_flag_rules.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load red flag rules: %w", err)
+	}
+
+	// Load yellow flag rules
+	yellowRules, err := LoadRuleFile(filepath.Join(cs.dataPath, "yellow_flag_rules.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load yellow flag rules: %w", err)
+	}
+
+	cs.setRules(redRules, yellowRules)
+	return nil
+}
+
+// LoadBlockFile reads an approved content file such as data/approved_block.json
+func LoadBlockFile(blocksPath string) ([]entities.ApprovedBlock, error) {
 	blocksData, err := os.ReadFile(blocksPath)
 	if err != nil {
-		return fmt.Errorf("failed to read approved blocks file: %w", err)
+		return nil, fmt.Errorf("failed to read approved blocks file: %w", err)
 	}
 
 	var blocks []entities.ApprovedBlock
 	if err := json.Unmarshal(blocksData, &blocks); err != nil {
-		return fmt.Errorf("failed to parse approved blocks JSON: %w", err)
+		return nil, fmt.Errorf("failed to parse approved blocks JSON: %w", err)
 	}
+	return blocks, nil
+}
 
-	cs.approvedBlocks = blocks
+// RefreshTopics reloads the served topics from the admin-managed collection. Only published
+// content is served; drafts, archived and soft-deleted topics are excluded by the repository.
+// approved_block.json is served only until a load succeeds; after that an empty result
+// clears the served topics, so taking down the last one takes effect too.
+func (cs *ContentService) RefreshTopics(ctx context.Context) error {
+	if cs.topicRepo == nil {
+		return nil
+	}
 
-	// Load red flag rules
-	if err := cs.loadRedFlagRules(); err != nil {
-		return fmt.Errorf("failed to load red flag rules: %w", err)
+	topics, err := cs.topicRepo.ListPublishedTopics(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list topics: %w", err)
+	}
+	if len(topics) == 0 {
+		log.Println("⚠️ No published topics in database, no topics are served")
 	}
 
-	// Load yellow flag rules
-	if err := cs.loadYellowFlagRules(); err != nil {
-		return fmt.Errorf("failed to load yellow flag rules: %w", err)
+	blocks := make([]entities.ApprovedBlock, 0, len(topics))
+	for _, topic := range topics {
+		blocks = append(blocks, topic.ApprovedBlock())
 	}
 
+	previous := cs.ContentVersion()
+	cs.setBlocks(blocks)
+	if current := cs.ContentVersion(); current != previous {
+		log.Printf("✅ Topics refreshed from database: %d published (version %s)", len(blocks), current)
+	}
 	return nil
 }
 
+// StartTopicRefresh periodically reloads the topics until ctx is cancelled
+func (cs *ContentService) StartTopicRefresh(ctx context.Context, interval time.Duration) {
+	if cs.topicRepo == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refreshCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+				if err := cs.RefreshTopics(refreshCtx); err != nil {
+					log.Printf("❌ Failed to refresh topics: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// setBlocks swaps in a new topic set, recomputes its version and reports missing translations
+func (cs *ContentService) setBlocks(blocks []entities.ApprovedBlock) {
+	data, _ := json.Marshal(blocks)
+	sum := sha256.Sum256(data)
+	version := hex.EncodeToString(sum[:6])
+
+	cs.mu.Lock()
+	changed := version != cs.topicsVersion
+	cs.approvedBlocks = blocks
+	cs.topicsVersion = version
+	cs.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	// Every enabled language should have content; report gaps instead of failing requests silently
+	for _, block := range blocks {
+		for _, code := range lang.Codes() {
+			if _, ok := block.Translations[code]; !ok {
+				log.Printf("⚠️ Topic %s has no %s (%s) translation", block.TopicKey, lang.Name(code), code)
+			}
+		}
+	}
+}
+
 // returns all approved blocks
 func (cs *ContentService) GetApprovedBlocks() ([]entities.ApprovedBlock, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
 	if len(cs.approvedBlocks) == 0 {
 		return nil, fmt.Errorf("no approved blocks loaded")
 	}
 	return cs.approvedBlocks, nil
 }
 
+// TopicKeys returns the keys of the topics currently served
+func (cs *ContentService) TopicKeys() []string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	keys := make([]string, len(cs.approvedBlocks))
+	for i, block := range cs.approvedBlocks {
+		keys[i] = block.TopicKey
+	}
+	return keys
+}
+
+// ContentVersion returns a short fingerprint of the topics currently served
+func (cs *ContentService) ContentVersion() string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.topicsVersion
+}
+
 // returns content for a specific topic and language
 func (cs *ContentService) GetContentByTopic(topicKey, language string) (*entities.ContentTranslation, error) {
+	if !lang.IsSupported(language) {
+		return nil, derrors.ErrUnsupportedLanguage
+	}
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
 	for _, block := range cs.approvedBlocks {
 		if block.TopicKey == topicKey {
 			if content, exists := block.Translations[language]; exists {
@@ -82,65 +232,153 @@ func (cs *ContentService) GetContentByTopic(topicKey, language string) (*entitie
 	return nil, derrors.ErrTopicNotFound
 }
 
-// loads red flag rules from JSON file only
-func (cs *ContentService) loadRedFlagRules() error {
-	rulesPath := filepath.Join(cs.dataPath, "red_flag_rules.json")
+// LoadRuleFile reads a red/yellow flag rule file such as data/red_flag_rules.json
+func LoadRuleFile(rulesPath string) ([]entities.RedFlagRule, error) {
 	rulesData, err := os.ReadFile(rulesPath)
 	if err != nil {
-		return fmt.Errorf("failed to read red flag rules file: %w", err)
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
 	}
 
 	var rules []entities.RedFlagRule
 	if err := json.Unmarshal(rulesData, &rules); err != nil {
-		return fmt.Errorf("failed to parse red flag rules JSON: %w", err)
+		return nil, fmt.Errorf("failed to parse rules JSON: %w", err)
 	}
 
-	cs.redFlagRules = rules
-	fmt.Printf("✅ Loaded %d red flag rules from %s\n", len(rules), rulesPath)
-	return nil
+	// The ID is what seeding and triage audits refer to, so it must stay fixed when a rule is edited
+	seen := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule %d in %s has no id", i, rulesPath)
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("duplicate rule id %q in %s", rule.ID, rulesPath)
+		}
+		seen[rule.ID] = true
+	}
+
+	fmt.Printf("✅ Loaded %d rules from %s\n", len(rules), rulesPath)
+	return rules, nil
 }
 
-// load yellow flag rules from separate file
-func (cs *ContentService) loadYellowFlagRules() error {
-	rulesPath := filepath.Join(cs.dataPath, "yellow_flag_rules.json")
-	rulesData, err := os.ReadFile(rulesPath)
+// RefreshRules reloads the red/yellow flag rules from the admin-managed collection.
+// Soft-deleted rules are excluded by the repository. The JSON rules are used only until a load
+// succeeds; after that an empty result clears the rules, so deleting the last one takes effect too.
+func (cs *ContentService) RefreshRules(ctx context.Context) error {
+	if cs.redFlagRepo == nil {
+		return nil
+	}
+
+	redFlags, err := cs.redFlagRepo.List(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read yellow flag rules file: %w", err)
+		return fmt.Errorf("failed to list red flags: %w", err)
+	}
+	if len(redFlags) == 0 {
+		log.Println("⚠️ No red flag rules in database, triage runs without keyword rules")
 	}
 
-	var rules []entities.RedFlagRule
-	if err := json.Unmarshal(rulesData, &rules); err != nil {
-		return fmt.Errorf("failed to parse yellow flag rules JSON: %w", err)
+	var redRules, yellowRules []entities.RedFlagRule
+	for _, rf := range redFlags {
+		switch rf.Level {
+		case entities.TriageLevelRed:
+			redRules = append(redRules, rf.Rule())
+		case entities.TriageLevelYellow:
+			yellowRules = append(yellowRules, rf.Rule())
+		}
 	}
 
-	cs.yellowFlagRules = rules
-	fmt.Printf("✅ Loaded %d yellow flag rules from %s\n", len(rules), rulesPath)
+	previous := cs.RulesVersion()
+	cs.setRules(redRules, yellowRules)
+	if current := cs.RulesVersion(); current != previous {
+		log.Printf("✅ Triage rules refreshed from database: %d red, %d yellow (version %s)", len(redRules), len(yellowRules), current)
+	}
 	return nil
 }
 
+// StartRuleRefresh periodically reloads the rules until ctx is cancelled
+func (cs *ContentService) StartRuleRefresh(ctx context.Context, interval time.Duration) {
+	if cs.redFlagRepo == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refreshCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+				if err := cs.RefreshRules(refreshCtx); err != nil {
+					log.Printf("❌ Failed to refresh triage rules: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// RulesVersion returns a short fingerprint of the rules currently in use
+func (cs *ContentService) RulesVersion() string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.rulesVersion
+}
+
+// RuleSnapshot returns the current red and yellow rules with their version
+func (cs *ContentService) RuleSnapshot() entities.TriageRuleSnapshot {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return entities.TriageRuleSnapshot{
+		Version:     cs.rulesVersion,
+		RedRules:    filterRulesByLevel(cs.redFlagRules, entities.TriageLevelRed),
+		YellowRules: filterRulesByLevel(cs.yellowFlagRules, entities.TriageLevelYellow),
+	}
+}
+
+// setRules swaps in a new rule set and recomputes its version
+func (cs *ContentService) setRules(redRules, yellowRules []entities.RedFlagRule) {
+	data, _ := json.Marshal(struct {
+		Red    []entities.RedFlagRule `json:"red"`
+		Yellow []entities.RedFlagRule `json:"yellow"`
+	}{redRules, yellowRules})
+	sum := sha256.Sum256(data)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.redFlagRules = redRules
+	cs.yellowFlagRules = yellowRules
+	cs.rulesVersion = hex.EncodeToString(sum[:6])
+}
+
 // returns the red flag rules for triage
 func (cs *ContentService) GetRedFlagRules() []entities.RedFlagRule {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
 	return cs.redFlagRules
 }
 
 // returns only red flag rules (level RED)
 func (cs *ContentService) GetRedFlagRulesOnly() []entities.RedFlagRule {
-	var redRules []entities.RedFlagRule
-	for _, rule := range cs.redFlagRules {
-		if rule.Level == entities.TriageLevelRed {
-			redRules = append(redRules, rule)
-		}
-	}
-	return redRules
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return filterRulesByLevel(cs.redFlagRules, entities.TriageLevelRed)
 }
 
 // returns only yellow flag rules (level YELLOW)
 func (cs *ContentService) GetYellowFlagRules() []entities.RedFlagRule {
-	var yellowRules []entities.RedFlagRule
-	for _, rule := range cs.yellowFlagRules {
-		if rule.Level == entities.TriageLevelYellow {
-			yellowRules = append(yellowRules, rule)
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return filterRulesByLevel(cs.yellowFlagRules, entities.TriageLevelYellow)
+}
+
+// filterRulesByLevel returns the rules with the given level
+func filterRulesByLevel(rules []entities.RedFlagRule, level entities.TriageLevel) []entities.RedFlagRule {
+	var filtered []entities.RedFlagRule
+	for _, rule := range rules {
+		if rule.Level == level {
+			filtered = append(filtered, rule)
 		}
 	}
-	return yellowRules
+	return filtered
 }
@@ -0,0 +1,192 @@
+// Package i18n loads the user-facing message bundles in data/locales so wording
+// can change without a code release.
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/util/lang"
+)
+
+// message is either a plain string or a set of plural forms ("zero", "one", "two", "few", "many", "other")
+type message struct {
+	text   string
+	plural map[string]string
+}
+
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &m.plural); err != nil {
+		return fmt.Errorf("message must be a string or an object of plural forms")
+	}
+	if _, ok := m.plural["other"]; !ok {
+		return fmt.Errorf("plural message needs an \"other\" form")
+	}
+	return nil
+}
+
+// Catalog holds one bundle per language, loaded from <dir>/<code>.json
+type Catalog struct {
+	dir     string
+	mu      sync.RWMutex
+	bundles map[string]map[string]message
+}
+
+// NewCatalog loads every bundle in dir. A missing or invalid bundle is an error
+// because user-facing text would silently fall back to the key.
+func NewCatalog(dir string) (*Catalog, error) {
+	c := &Catalog{dir: dir}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+var _ interfaces.MessageCatalog = (*Catalog)(nil)
+
+// Reload re-reads the bundles; on error the previously loaded bundles stay in use
+func (c *Catalog) Reload() error {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no message bundles found in %s", c.dir)
+	}
+
+	bundles := make(map[string]map[string]message, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read message bundle: %w", err)
+		}
+		var bundle map[string]message
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return fmt.Errorf("failed to parse message bundle %s: %w", file, err)
+		}
+		code := strings.TrimSuffix(filepath.Base(file), ".json")
+		bundles[code] = bundle
+	}
+
+	// Point out keys the default language has but an enabled language lacks
+	if base, ok := bundles[lang.Default().DefaultCode()]; ok {
+		for _, code := range lang.Codes() {
+			for key := range base {
+				if _, ok := bundles[code][key]; !ok {
+					log.Printf("⚠️ Message %q has no %s translation, falling back", key, code)
+				}
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.bundles = bundles
+	c.mu.Unlock()
+	return nil
+}
+
+// StartReload re-reads the bundles every interval so edited wording goes live without a restart
+func (c *Catalog) StartReload(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.Reload(); err != nil {
+					log.Printf("❌ Failed to reload message bundles: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Message formats the text for key in the first language of the fallback chain that has it
+func (c *Catalog) Message(language, key string, params map[string]interface{}) string {
+	msg, _, ok := c.lookup(language, key)
+	if !ok {
+		return key
+	}
+	text := msg.text
+	if msg.plural != nil {
+		text = msg.plural["other"]
+	}
+	return format(text, params)
+}
+
+// Plural picks the plural form for count using the rules of the language the text came from
+func (c *Catalog) Plural(language, key string, count int, params map[string]interface{}) string {
+	msg, found, ok := c.lookup(language, key)
+	if !ok {
+		return key
+	}
+	withCount := map[string]interface{}{"count": count}
+	for k, v := range params {
+		withCount[k] = v
+	}
+	if msg.plural == nil {
+		return format(msg.text, withCount)
+	}
+	text, ok := msg.plural[pluralCategory(found, count)]
+	if !ok {
+		text = msg.plural["other"]
+	}
+	return format(text, withCount)
+}
+
+// lookup returns the message and the language it was found in
+func (c *Catalog) lookup(language, key string) (message, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, code := range lang.Default().Chain(language) {
+		if msg, ok := c.bundles[code][key]; ok {
+			return msg, code, true
+		}
+	}
+	return message{}, "", false
+}
+
+// format replaces {name} placeholders with params
+func format(text string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// pluralCategory follows the CLDR cardinal rules for integer counts.
+// Amharic and Tigrinya treat 0 and 1 as "one"; English and Afaan Oromo only 1.
+func pluralCategory(language string, count int) string {
+	switch language {
+	case "am", "ti":
+		if count == 0 || count == 1 {
+			return "one"
+		}
+	default:
+		if count == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,131 @@
+package lexical
+
+import (
+	"math"
+	"sort"
+)
+
+// BM25 parameters: k1 limits how much a repeated term counts, b how much long documents are penalised
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field is a piece of document text; Weight repeats its terms so names and synonyms count more than body text
+type Field struct {
+	Text   string
+	Weight int
+	// Label marks text that names the document (a title or synonym) rather than describing it
+	Label bool
+}
+
+// Document is one searchable item, such as a topic
+type Document struct {
+	Key    string
+	Fields []Field
+}
+
+// Match is a document with its BM25 score for a query
+type Match struct {
+	Key   string
+	Score float64
+	// Labeled is set when a query term occurs in one of the document's label fields
+	Labeled bool
+}
+
+// Index is an immutable BM25 index
+type Index struct {
+	terms     map[string]map[string]int // term -> document key -> frequency
+	labels    map[string]map[string]bool
+	lengths   map[string]int
+	avgLength float64
+}
+
+// NewIndex tokenizes and indexes the documents
+func NewIndex(docs []Document) *Index {
+	idx := &Index{
+		terms:   make(map[string]map[string]int),
+		labels:  make(map[string]map[string]bool),
+		lengths: make(map[string]int, len(docs)),
+	}
+	total := 0
+	for _, doc := range docs {
+		for _, field := range doc.Fields {
+			weight := field.Weight
+			if weight < 1 {
+				weight = 1
+			}
+			for _, term := range Tokenize(field.Text) {
+				if idx.terms[term] == nil {
+					idx.terms[term] = make(map[string]int)
+				}
+				idx.terms[term][doc.Key] += weight
+				if field.Label {
+					if idx.labels[term] == nil {
+						idx.labels[term] = make(map[string]bool)
+					}
+					idx.labels[term][doc.Key] = true
+				}
+				idx.lengths[doc.Key] += weight
+				total += weight
+			}
+		}
+	}
+	if len(idx.lengths) > 0 {
+		idx.avgLength = float64(total) / float64(len(idx.lengths))
+	}
+	return idx
+}
+
+// Search scores the documents against the query, best first. keys limits the result to
+// those documents (nil searches all); documents without a matching term are left out.
+func (idx *Index) Search(query string, keys []string) []Match {
+	var allowed map[string]bool
+	if keys != nil {
+		allowed = make(map[string]bool, len(keys))
+		for _, k := range keys {
+			allowed[k] = true
+		}
+	}
+
+	scores := make(map[string]float64)
+	labeled := make(map[string]bool)
+	seen := make(map[string]bool)
+	n := float64(len(idx.lengths))
+	for _, term := range Tokenize(query) {
+		// A repeated query word is not stronger evidence
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		postings := idx.terms[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for key, freq := range postings {
+			if allowed != nil && !allowed[key] {
+				continue
+			}
+			tf := float64(freq)
+			norm := 1 - bm25B + bm25B*float64(idx.lengths[key])/idx.avgLength
+			scores[key] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+			if idx.labels[term][key] {
+				labeled[key] = true
+			}
+		}
+	}
+
+	matches := make([]Match, 0, len(scores))
+	for key, score := range scores {
+		matches = append(matches, Match{Key: key, Score: score, Labeled: labeled[key]})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Key < matches[j].Key
+	})
+	return matches
+}
@@ -0,0 +1,139 @@
+// Package lexical is a small BM25 search over short English and Amharic texts, used to
+// map symptom descriptions to topics without calling the LLM.
+package lexical
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize splits text into normalized index terms. English words are lowercased and
+// lightly stemmed. Amharic (Ethiopic script) words are reduced to their consonants,
+// since the vowel of the last syllable changes with possessive and case endings
+// (ራስ "head", ራሴን "my head"), with homophone letters merged and common suffixes
+// removed; a word with a preposition prefix (የ, በ, ለ, ከ) also yields the bare word.
+func Tokenize(text string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(text, isSeparator) {
+		if isEthiopic(word) {
+			for _, term := range ethiopicTerms(word) {
+				if !stopwords[term] {
+					terms = append(terms, term)
+				}
+			}
+			continue
+		}
+		word = strings.ToLower(word)
+		if stopwords[word] || len(word) < 2 {
+			continue
+		}
+		terms = append(terms, stemEnglish(word))
+	}
+	return terms
+}
+
+// isSeparator splits on anything but letters, digits and combining marks; Ethiopic
+// punctuation (። ፣ ፤ and the ፡ word space) is not a letter so it separates words too
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+}
+
+func isEthiopic(word string) bool {
+	for _, r := range word {
+		if unicode.Is(unicode.Ethiopic, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ethiopicSuffixes are possessive, plural and object endings after reduction to
+// consonants, longest first: -ኦች/-ዎች (plural), -ን (object), -ዬ (my), -ው/-ዋ (his/her), -ም
+var ethiopicSuffixes = []string{"አቸነ", "ወቸነ", "አቸ", "ወቸ", "ቸ", "የነ", "ወነ", "ነ", "የ", "ወ", "መ"}
+
+// ethiopicPrefixes are prepositions written attached to the noun: of, in/with, for, from
+var ethiopicPrefixes = []string{"የ", "በ", "ለ", "ከ"}
+
+// ethiopicTerms returns the index terms for an Amharic word
+func ethiopicTerms(word string) []string {
+	skeleton := []rune(consonants(word))
+	for _, suffix := range ethiopicSuffixes {
+		s := []rune(suffix)
+		if len(skeleton)-len(s) >= 2 && string(skeleton[len(skeleton)-len(s):]) == suffix {
+			skeleton = skeleton[:len(skeleton)-len(s)]
+			break
+		}
+	}
+	terms := []string{string(skeleton)}
+	for _, prefix := range ethiopicPrefixes {
+		if len(skeleton) >= 3 && string(skeleton[0]) == prefix {
+			terms = append(terms, string(skeleton[1:]))
+			break
+		}
+	}
+	return terms
+}
+
+// consonants maps each Ethiopic syllable to the first form of its row (ሱ, ሲ, ሳ, ስ → ሰ)
+// and merges the letters that are pronounced the same in Amharic (ሐ, ኀ → ሀ; ሠ → ሰ; ዐ → አ; ፀ → ጸ)
+func consonants(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		if r >= 0x1200 && r <= 0x135A {
+			r = 0x1200 + (r-0x1200)/8*8
+			switch r {
+			case 0x1210, 0x1280:
+				r = 0x1200
+			case 0x1220:
+				r = 0x1230
+			case 0x12D0:
+				r = 0x12A0
+			case 0x1340:
+				r = 0x1338
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// englishSuffixes are stripped once, first match wins; the result keeps at least three letters
+var englishSuffixes = []struct{ suffix, replacement string }{
+	{"ies", "y"}, {"ing", ""}, {"edly", ""}, {"ed", ""}, {"es", ""}, {"ly", ""}, {"s", ""},
+}
+
+// stemEnglish is a light stemmer: "aches", "ached" and "aching" all become "ach"
+func stemEnglish(word string) string {
+	for _, s := range englishSuffixes {
+		if strings.HasSuffix(word, s.suffix) && len(word)-len(s.suffix)+len(s.replacement) >= 3 {
+			word = word[:len(word)-len(s.suffix)] + s.replacement
+			break
+		}
+	}
+	if len(word) > 3 && strings.HasSuffix(word, "e") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+// stopwords carry no topic signal; "hurts" and ያመኛል ("it hurts me") say nothing about
+// where. Amharic entries are stored reduced to consonants, so a word whose consonants
+// collide with a symptom word (ስለ "about" and ሳል "cough") must stay off the list.
+var stopwords = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(`a about after all also am an and any are as at be been before but
+		by can could did do does doing for from get got had has have having he her him his how i if in
+		into is it its just me more most my no not now of on or our she so some than that the their them
+		then there these they this to too very was we were what when where which while who will with
+		would you your since really bit little lot feel feeling felt like today yesterday morning night
+		day days week weeks hour hours hurt hurts hurting`) {
+		set[w] = true
+	}
+	for _, w := range strings.Fields(`እኔ እኛ አንተ አንቺ እሱ እሷ ነው ነኝ ናቸው ነበር ነበረ አለ አለኝ አለብኝ አለበት እና ግን ወይም
+		በጣም ትንሽ ላይ ውስጥ ጋር ይህ ያ ያለ ከ ወደ ዛሬ ትላንት ጠዋት ማታ ቀን ቀናት ሳምንት ያመኛል ያመዋል ያማል`) {
+		for _, term := range ethiopicTerms(w) {
+			set[term] = true
+		}
+	}
+	return set
+}()
@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+)
+
+// MemoryCacheStore keeps cached replies in process, evicting the least recently used
+// entry once MaxEntries is reached. Entries are lost on restart.
+type MemoryCacheStore struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+// NewMemoryCacheStore creates an in-memory cache; maxEntries 0 is unlimited
+func NewMemoryCacheStore(maxEntries int) *MemoryCacheStore {
+	return &MemoryCacheStore{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+var _ interfaces.LLMCacheStore = (*MemoryCacheStore)(nil)
+
+func (m *MemoryCacheStore) Get(ctx context.Context, key string) (*entities.LLMCacheEntry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*entities.LLMCacheEntry)
+	if entry.Expired(time.Now()) {
+		m.remove(elem)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(elem)
+	copied := *entry
+	return &copied, true, nil
+}
+
+func (m *MemoryCacheStore) Set(ctx context.Context, entry *entities.LLMCacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *entry
+	if elem, ok := m.entries[entry.Key]; ok {
+		elem.Value = &copied
+		m.order.MoveToFront(elem)
+		return nil
+	}
+	m.entries[entry.Key] = m.order.PushFront(&copied)
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+func (m *MemoryCacheStore) DeleteScope(ctx context.Context, scope, keepVersion string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	for elem := m.order.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*entities.LLMCacheEntry)
+		if entry.Scope == scope && entry.Version != keepVersion {
+			m.remove(elem)
+			deleted++
+		}
+		elem = next
+	}
+	return deleted, nil
+}
+
+func (m *MemoryCacheStore) Count(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(m.order.Len()), nil
+}
+
+func (m *MemoryCacheStore) remove(elem *list.Element) {
+	m.order.Remove(elem)
+	delete(m.entries, elem.Value.(*entities.LLMCacheEntry).Key)
+}
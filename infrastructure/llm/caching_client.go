@@ -0,0 +1,205 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+)
+
+// Cache backends
+const (
+	CacheBackendMemory = "memory"
+	CacheBackendMongo  = "mongo"
+	CacheBackendOff    = "off"
+)
+
+// CachingClient serves repeated LLM requests from a cache. Only requests with a
+// CacheScope are cached; the key covers the prompt, the model and every generation
+// option. Prompts differing only in spacing share an entry, but case and punctuation
+// count, since "no fever." and "no. Fever" need not be triaged the same way.
+type CachingClient struct {
+	inner  interfaces.LLMClient
+	store  interfaces.LLMCacheStore
+	config dto.LLMCacheConfig
+	model  string
+
+	mu       sync.Mutex
+	versions map[string]string // latest CacheVersion this process has seen per scope
+	scopes   map[string]*dto.LLMCacheScopeStats
+}
+
+// NewCachingClient wraps inner with a response cache for the given model
+func NewCachingClient(inner interfaces.LLMClient, store interfaces.LLMCacheStore, config dto.LLMCacheConfig, model string) *CachingClient {
+	return &CachingClient{
+		inner:    inner,
+		store:    store,
+		config:   config,
+		model:    model,
+		versions: make(map[string]string),
+		scopes:   make(map[string]*dto.LLMCacheScopeStats),
+	}
+}
+
+var _ interfaces.LLMClient = (*CachingClient)(nil)
+var _ interfaces.LLMCacheStatsProvider = (*CachingClient)(nil)
+
+// Generate returns the cached reply when there is one, otherwise calls the wrapped client
+// and caches a complete reply. Cache failures are logged and never fail the call.
+func (cc *CachingClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	if req.CacheScope == "" {
+		return cc.inner.Generate(ctx, req)
+	}
+
+	cc.invalidateOldVersions(ctx, req.CacheScope, req.CacheVersion)
+
+	key := CacheKey(cc.model, req)
+	if entry, ok, err := cc.store.Get(ctx, key); err != nil {
+		log.Printf("⚠️ LLM cache lookup failed: %v", err)
+	} else if ok {
+		cc.count(req.CacheScope, true)
+		return &dto.GenerateResponse{
+			Text:         entry.Text,
+			Model:        entry.Model,
+			Provider:     entry.Provider,
+			FinishReason: entry.FinishReason,
+			Cached:       true,
+		}, nil
+	}
+	cc.count(req.CacheScope, false)
+
+	resp, err := cc.inner.Generate(ctx, req)
+	if err != nil || truncated(resp.FinishReason) {
+		return resp, err
+	}
+
+	now := time.Now()
+	entry := &entities.LLMCacheEntry{
+		Key:          key,
+		Scope:        req.CacheScope,
+		Version:      req.CacheVersion,
+		Model:        resp.Model,
+		Provider:     resp.Provider,
+		Text:         resp.Text,
+		FinishReason: resp.FinishReason,
+		CreatedAt:    now,
+	}
+	if cc.config.TTL > 0 {
+		entry.ExpiresAt = now.Add(cc.config.TTL)
+	}
+	if err := cc.store.Set(ctx, entry); err != nil {
+		log.Printf("⚠️ LLM cache write failed: %v", err)
+	}
+	return resp, nil
+}
+
+// CacheStats returns hit/miss counts since startup and the current number of entries
+func (cc *CachingClient) CacheStats(ctx context.Context) (dto.LLMCacheStats, error) {
+	entries, err := cc.store.Count(ctx)
+	if err != nil {
+		return dto.LLMCacheStats{}, err
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	stats := dto.LLMCacheStats{
+		Backend: cc.config.Backend,
+		Entries: entries,
+		Scopes:  make(map[string]dto.LLMCacheScopeStats, len(cc.scopes)),
+	}
+	for scope, s := range cc.scopes {
+		stats.Hits += s.Hits
+		stats.Misses += s.Misses
+		stats.Scopes[scope] = *s
+	}
+	stats.HitRate = hitRate(stats.Hits, stats.Misses)
+	return stats, nil
+}
+
+// invalidateOldVersions drops a scope's entries when this process moves to a new version of
+// its data. The first version a process sees is just recorded: other instances sharing the
+// store may still be on another version, and the version in the key keeps their entries apart.
+func (cc *CachingClient) invalidateOldVersions(ctx context.Context, scope, version string) {
+	cc.mu.Lock()
+	previous, seen := cc.versions[scope]
+	cc.versions[scope] = version
+	cc.mu.Unlock()
+	if !seen || previous == version {
+		return
+	}
+
+	deleted, err := cc.store.DeleteScope(ctx, scope, version)
+	if err != nil {
+		log.Printf("⚠️ Failed to invalidate %s cache entries: %v", scope, err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("✅ Invalidated %d cached %s replies (now at version %s)", deleted, scope, version)
+	}
+}
+
+func (cc *CachingClient) count(scope string, hit bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	s, ok := cc.scopes[scope]
+	if !ok {
+		s = &dto.LLMCacheScopeStats{}
+		cc.scopes[scope] = s
+	}
+	if hit {
+		s.Hits++
+	} else {
+		s.Misses++
+	}
+	s.HitRate = hitRate(s.Hits, s.Misses)
+}
+
+// CacheKey hashes the model, the whitespace-collapsed prompt and every option that affects the reply
+func CacheKey(model string, req dto.GenerateRequest) string {
+	messages := make([]dto.LLMMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = dto.LLMMessage{Role: m.Role, Content: collapseSpace(m.Content)}
+	}
+	data, _ := json.Marshal(struct {
+		Model       string
+		Scope       string
+		Version     string
+		System      string
+		Messages    []dto.LLMMessage
+		JSONMode    bool
+		MaxTokens   int
+		Temperature *float32
+		Stop        []string
+	}{model, req.CacheScope, req.CacheVersion, collapseSpace(req.System), messages, req.JSONMode, req.MaxTokens, req.Temperature, req.StopSequences})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// collapseSpace trims the text and collapses runs of whitespace to a single space
+func collapseSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// truncated reports a reply cut off by the token limit, which is not worth caching
+func truncated(finishReason string) bool {
+	switch strings.ToLower(finishReason) {
+	case "length", "max_tokens":
+		return true
+	}
+	return false
+}
+
+func hitRate(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
@@ -0,0 +1,169 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+// ErrCassetteMiss is returned in replay mode for a prompt the cassette has no recording for
+var ErrCassetteMiss = errors.New("no recorded LLM response for prompt")
+
+// CassetteInteraction is one recorded LLM call. The system prompt is kept for reference
+// only; replay matches on the feature and the normalized messages, so editing rules or
+// instructions does not invalidate a cassette.
+type CassetteInteraction struct {
+	Feature  string `json:"feature,omitempty"`
+	System   string `json:"system,omitempty"`
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Cassette is the file format written by the recording client
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteClient records LLM calls to a cassette file or replays them from one.
+// Repeated prompts replay their recordings in order, repeating the last one.
+type CassetteClient struct {
+	path  string
+	inner interfaces.LLMClient // nil when replaying
+
+	mu       sync.Mutex
+	recorded []CassetteInteraction
+	replay   map[string][]CassetteInteraction
+	played   map[string]int
+	misses   []string
+}
+
+// NewRecordingClient passes calls through to inner and records them; call Save to write the cassette
+func NewRecordingClient(inner interfaces.LLMClient, path string) *CassetteClient {
+	return &CassetteClient{path: path, inner: inner}
+}
+
+// LoadCassette replays a recorded cassette without calling any provider
+func LoadCassette(path string) (*CassetteClient, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+
+	c := &CassetteClient{
+		path:   path,
+		replay: make(map[string][]CassetteInteraction),
+		played: make(map[string]int),
+	}
+	for _, interaction := range cassette.Interactions {
+		key := cassetteKey(interaction.Feature, interaction.Prompt)
+		c.replay[key] = append(c.replay[key], interaction)
+	}
+	return c, nil
+}
+
+var _ interfaces.LLMClient = (*CassetteClient)(nil)
+
+func (c *CassetteClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	prompt := cassettePrompt(req)
+	if c.inner == nil {
+		return c.play(req.Feature, prompt)
+	}
+
+	resp, err := c.inner.Generate(ctx, req)
+	interaction := CassetteInteraction{Feature: req.Feature, System: req.System, Prompt: prompt}
+	if err != nil {
+		interaction.Error = err.Error()
+	} else {
+		interaction.Response = resp.Text
+	}
+	c.mu.Lock()
+	c.recorded = append(c.recorded, interaction)
+	c.mu.Unlock()
+	return resp, err
+}
+
+// Save writes the recorded interactions to the cassette file
+func (c *CassetteClient) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(Cassette{Interactions: c.recorded}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+// Misses returns the prompts that had no recording, so tests can fail on them even
+// when the caller fell back gracefully
+func (c *CassetteClient) Misses() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.misses...)
+}
+
+func (c *CassetteClient) play(feature, prompt string) (*dto.GenerateResponse, error) {
+	key := cassetteKey(feature, prompt)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	recordings := c.replay[key]
+	if len(recordings) == 0 {
+		c.misses = append(c.misses, prompt)
+		log.Printf("❌ Cassette %s has no %q recording for prompt:\n%s", c.path, feature, prompt)
+		return nil, fmt.Errorf("%w (feature %q) in %s: %.120q", ErrCassetteMiss, feature, c.path, prompt)
+	}
+
+	i := c.played[key]
+	if i >= len(recordings) {
+		i = len(recordings) - 1
+	}
+	c.played[key]++
+
+	interaction := recordings[i]
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+	return &dto.GenerateResponse{Text: interaction.Response, Model: "cassette", FinishReason: "stop"}, nil
+}
+
+// cassettePrompt joins the request messages as they are recorded
+func cassettePrompt(req dto.GenerateRequest) string {
+	parts := make([]string, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		parts = append(parts, m.Content)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func cassetteKey(feature, prompt string) string {
+	return feature + "\n" + normalizePrompt(prompt)
+}
+
+// normalizePrompt lowercases, drops punctuation (including Ethiopic marks) and collapses whitespace
+func normalizePrompt(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+// Supported LLM providers
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+)
+
+// NewClient creates the client for the configured provider
+func NewClient(config dto.LLMConfig) (interfaces.LLMClient, error) {
+	if config.Model == "" {
+		return nil, fmt.Errorf("%s provider needs a model", config.Provider)
+	}
+	switch strings.ToLower(config.Provider) {
+	case "", ProviderGemini:
+		if config.APIKey == "" {
+			return nil, fmt.Errorf("gemini provider needs an API key")
+		}
+		return NewGeminiClient(config), nil
+	case ProviderOpenAI:
+		if config.BaseURL == "" && config.APIKey == "" {
+			return nil, fmt.Errorf("openai provider needs an API key or a base URL")
+		}
+		return NewOpenAIClient(config), nil
+	case ProviderOllama:
+		return NewOllamaClient(config), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider %q (supported: %s, %s, %s)", config.Provider, ProviderGemini, ProviderOpenAI, ProviderOllama)
+	}
+}
+
+// temperatureOrDefault returns the request temperature, or the configured one when unset
+func temperatureOrDefault(req dto.GenerateRequest, config dto.LLMConfig) float32 {
+	if req.Temperature != nil {
+		return *req.Temperature
+	}
+	return config.Temperature
+}
+
+// maxTokensOrDefault returns the request limit, or the configured one when unset
+func maxTokensOrDefault(req dto.GenerateRequest, config dto.LLMConfig) int {
+	if req.MaxTokens > 0 {
+		return req.MaxTokens
+	}
+	return config.MaxTokens
+}
+
+// chatMessages flattens the request into role/content messages with the system prompt first
+func chatMessages(req dto.GenerateRequest) []dto.OpenAIChatMessage {
+	messages := make([]dto.OpenAIChatMessage, 0, len(req.Messages)+1)
+	if req.System != "" {
+		messages = append(messages, dto.OpenAIChatMessage{Role: "system", Content: req.System})
+	}
+	for _, m := range req.Messages {
+		messages = append(messages, dto.OpenAIChatMessage{Role: m.Role, Content: m.Content})
+	}
+	return messages
+}
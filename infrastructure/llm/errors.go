@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ProviderError is a non-200 reply from an LLM provider
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	// RetryAfter is the wait the provider asked for, 0 when it did not say
+	RetryAfter time.Duration
+	Body       string
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s API returned status %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Retryable reports whether the request may succeed if repeated
+func (e *ProviderError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// newProviderError reads the failed response into a ProviderError
+func newProviderError(provider string, resp *http.Response, body []byte) *ProviderError {
+	return &ProviderError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Body:       string(body),
+	}
+}
+
+// parseRetryAfter accepts both forms of the header: delay seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// isRetryable reports whether err is a transient provider or network failure,
+// including a single attempt running into the HTTP client timeout
+func isRetryable(err error) bool {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Retryable()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// transportError strips the query and credentials from the URL in a failed request's error.
+// The error text ends up in audit records, usage records and the provider status endpoint,
+// and a query string can carry an API key.
+func transportError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	redacted := urlErr.URL
+	if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		u.User, u.RawQuery, u.Fragment = nil, "", ""
+		redacted = u.String()
+	} else {
+		redacted = "<request URL>"
+	}
+	return &url.Error{Op: urlErr.Op, URL: redacted, Err: urlErr.Err}
+}
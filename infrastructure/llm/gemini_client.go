@@ -13,6 +13,8 @@ import (
 	"remedymate-backend/domain/interfaces"
 )
 
+const defaultGeminiBaseURL = "https://generativelanguage.googleapis.com/v1beta/models"
+
 // GeminiClient implements LLMClient using Google's Gemini API
 type GeminiClient struct {
 	config     dto.LLMConfig
@@ -22,82 +24,110 @@ type GeminiClient struct {
 
 // NewGeminiClient creates a new Gemini client
 func NewGeminiClient(config dto.LLMConfig) interfaces.LLMClient {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGeminiBaseURL
+	}
 	return &GeminiClient{
 		config:     config,
 		httpClient: &http.Client{Timeout: time.Duration(config.Timeout) * time.Second},
-		baseURL:    "https://generativelanguage.googleapis.com/v1beta/models",
+		baseURL:    baseURL,
 	}
 }
 
-// ClassifyTriage calls Gemini API for triage classification
-func (g *GeminiClient) ClassifyTriage(ctx context.Context, prompt string) (string, error) {
-	return g.callGemini(ctx, prompt)
-}
-
-// callGemini makes the actual API call to Gemini
-func (g *GeminiClient) callGemini(ctx context.Context, prompt string) (string, error) {
+// Generate calls the Gemini generateContent API
+func (g *GeminiClient) Generate(ctx context.Context, request dto.GenerateRequest) (*dto.GenerateResponse, error) {
 	// 1. Construct the request for Gemini's API format
 	geminiReq := dto.GeminiRequest{
-		Contents: []dto.GeminiContent{
-			{
-				Parts: []dto.GeminiPart{{Text: prompt}},
-				Role:  "user",
-			},
-		},
 		GenerationConfig: dto.GeminiGenConfig{
-			Temperature:     g.config.Temperature,
-			MaxOutputTokens: g.config.MaxTokens,
+			Temperature:     temperatureOrDefault(request, g.config),
+			MaxOutputTokens: maxTokensOrDefault(request, g.config),
 			TopP:            0.95,
+			StopSequences:   request.StopSequences,
 		},
 		SafetySettings: []dto.GeminiSafetySetting{
 			{Category: "HARM_CATEGORY_DANGEROUS_CONTENT", Threshold: "BLOCK_MEDIUM_AND_ABOVE"},
 			{Category: "HARM_CATEGORY_HARASSMENT", Threshold: "BLOCK_MEDIUM_AND_ABOVE"},
 		},
 	}
+	if request.System != "" {
+		geminiReq.SystemInstruction = &dto.GeminiContent{Parts: []dto.GeminiPart{{Text: request.System}}}
+	}
+	for _, m := range request.Messages {
+		role := "user"
+		if m.Role == dto.LLMRoleAssistant {
+			role = "model"
+		}
+		geminiReq.Contents = append(geminiReq.Contents, dto.GeminiContent{
+			Parts: []dto.GeminiPart{{Text: m.Content}},
+			Role:  role,
+		})
+	}
+	if request.JSONMode {
+		geminiReq.GenerationConfig.ResponseMimeType = "application/json"
+	}
 
 	jsonData, err := json.Marshal(geminiReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// 2. Build the HTTP request
-	modelURL := fmt.Sprintf("%s/%s:generateContent?key=%s", g.baseURL, g.config.Model, g.config.APIKey)
+	modelURL := fmt.Sprintf("%s/%s:generateContent", g.baseURL, g.config.Model)
 	req, err := http.NewRequestWithContext(ctx, "POST", modelURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	// The key goes in a header so it never shows up in a logged or stored request URL
+	req.Header.Set("x-goog-api-key", g.config.APIKey)
 
 	// 3. Execute the request
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %w", err)
+		return nil, fmt.Errorf("API request failed: %w", transportError(err))
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newProviderError(ProviderGemini, resp, body)
 	}
 
 	// 4. Parse the Gemini response
 	var geminiResp dto.GeminiResponse
 	if err := json.Unmarshal(body, &geminiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if geminiResp.Error != nil {
-		return "", fmt.Errorf("gemini API error: %s", geminiResp.Error.Message)
+		return nil, fmt.Errorf("gemini API error: %s", geminiResp.Error.Message)
 	}
 
 	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("no response content returned from Gemini")
+		return nil, fmt.Errorf("no response content returned from Gemini")
 	}
 
 	// 5. Return the generated text
-	return geminiResp.Candidates[0].Content.Parts[0].Text, nil
+	candidate := geminiResp.Candidates[0]
+	out := &dto.GenerateResponse{
+		Text:         candidate.Content.Parts[0].Text,
+		Model:        g.config.Model,
+		FinishReason: candidate.FinishReason,
+	}
+	if geminiResp.ModelVersion != "" {
+		out.Model = geminiResp.ModelVersion
+	}
+	if u := geminiResp.UsageMetadata; u != nil {
+		out.Usage = dto.LLMUsage{
+			PromptTokens:     u.PromptTokenCount,
+			CompletionTokens: u.CandidatesTokenCount,
+			TotalTokens:      u.TotalTokenCount,
+		}
+	}
+	return out, nil
 }
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// OllamaClient implements LLMClient for a local Ollama-style server
+type OllamaClient struct {
+	config     dto.LLMConfig
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOllamaClient creates a client for an Ollama server
+func NewOllamaClient(config dto.LLMConfig) interfaces.LLMClient {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	return &OllamaClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: time.Duration(config.Timeout) * time.Second},
+		baseURL:    strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Generate calls the /api/chat endpoint without streaming
+func (o *OllamaClient) Generate(ctx context.Context, request dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	chatReq := dto.OllamaChatRequest{
+		Model:    o.config.Model,
+		Messages: chatMessages(request),
+		Stream:   false,
+		Options: dto.OllamaOptions{
+			Temperature: temperatureOrDefault(request, o.config),
+			NumPredict:  maxTokensOrDefault(request, o.config),
+			Stop:        request.StopSequences,
+		},
+	}
+	if request.JSONMode {
+		chatReq.Format = "json"
+	}
+
+	jsonData, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("API request failed: %w", transportError(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newProviderError(ProviderOllama, resp, body)
+	}
+
+	var chatResp dto.OllamaChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if chatResp.Error != "" {
+		return nil, fmt.Errorf("ollama error: %s", chatResp.Error)
+	}
+
+	model := chatResp.Model
+	if model == "" {
+		model = o.config.Model
+	}
+	return &dto.GenerateResponse{
+		Text:         chatResp.Message.Content,
+		Model:        model,
+		FinishReason: chatResp.DoneReason,
+		Usage: dto.LLMUsage{
+			PromptTokens:     chatResp.PromptEvalCount,
+			CompletionTokens: chatResp.EvalCount,
+			TotalTokens:      chatResp.PromptEvalCount + chatResp.EvalCount,
+		},
+	}, nil
+}
@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIClient implements LLMClient for any OpenAI-compatible chat completions endpoint
+type OpenAIClient struct {
+	config     dto.LLMConfig
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOpenAIClient creates a client for an OpenAI-compatible API
+func NewOpenAIClient(config dto.LLMConfig) interfaces.LLMClient {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: time.Duration(config.Timeout) * time.Second},
+		baseURL:    strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Generate calls the chat completions API
+func (o *OpenAIClient) Generate(ctx context.Context, request dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	chatReq := dto.OpenAIChatRequest{
+		Model:       o.config.Model,
+		Messages:    chatMessages(request),
+		MaxTokens:   maxTokensOrDefault(request, o.config),
+		Temperature: temperatureOrDefault(request, o.config),
+		Stop:        request.StopSequences,
+	}
+	if request.JSONMode {
+		chatReq.ResponseFormat = &dto.OpenAIResponseFormat{Type: "json_object"}
+	}
+
+	jsonData, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.config.APIKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("API request failed: %w", transportError(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newProviderError(ProviderOpenAI, resp, body)
+	}
+
+	var chatResp dto.OpenAIChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if chatResp.Error != nil {
+		return nil, fmt.Errorf("openai API error: %s", chatResp.Error.Message)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("no response content returned from %s", o.baseURL)
+	}
+
+	model := chatResp.Model
+	if model == "" {
+		model = o.config.Model
+	}
+	return &dto.GenerateResponse{
+		Text:         chatResp.Choices[0].Message.Content,
+		Model:        model,
+		FinishReason: chatResp.Choices[0].FinishReason,
+		Usage: dto.LLMUsage{
+			PromptTokens:     chatResp.Usage.PromptTokens,
+			CompletionTokens: chatResp.Usage.CompletionTokens,
+			TotalTokens:      chatResp.Usage.TotalTokens,
+		},
+	}, nil
+}
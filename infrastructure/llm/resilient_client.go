@@ -0,0 +1,250 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// ResilientClient wraps any LLMClient with retries, a concurrency cap and a
+// circuit breaker. When the provider keeps failing it fails fast with
+// AppError.ErrLLMDegraded instead of making callers wait on every request.
+type ResilientClient struct {
+	inner  interfaces.LLMClient
+	config dto.LLMResilienceConfig
+	slots  chan struct{}
+	name   string // provider name in logs, set by RouterClient
+
+	mu          sync.Mutex
+	state       string
+	failures    int
+	openedAt    time.Time
+	probeActive bool
+}
+
+// NewResilientClient wraps inner with the given resilience settings
+func NewResilientClient(inner interfaces.LLMClient, config dto.LLMResilienceConfig) *ResilientClient {
+	rc := &ResilientClient{
+		inner:  inner,
+		config: config,
+		state:  CircuitClosed,
+	}
+	if config.MaxConcurrent > 0 {
+		rc.slots = make(chan struct{}, config.MaxConcurrent)
+	}
+	return rc
+}
+
+var _ interfaces.LLMClient = (*ResilientClient)(nil)
+
+// Generate calls the wrapped client, retrying transient failures. A concurrency slot is
+// held only while a call is in flight, not while waiting to retry.
+func (rc *ResilientClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	if !rc.allow() {
+		return nil, fmt.Errorf("%w: circuit open after repeated provider failures", derrors.ErrLLMDegraded)
+	}
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if !rc.acquire(ctx) {
+			rc.release()
+			if lastErr == nil {
+				return nil, ctx.Err()
+			}
+			return nil, lastErr
+		}
+		resp, err := rc.inner.Generate(ctx, req)
+		rc.free()
+		if err == nil {
+			rc.recordSuccess()
+			return resp, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			if !attemptTimedOut(ctx) {
+				// The caller gave up; that says nothing about the provider
+				rc.release()
+				return nil, err
+			}
+			break
+		}
+		if !isRetryable(err) {
+			// The provider answered (e.g. a bad request), so it is up
+			rc.recordSuccess()
+			return nil, err
+		}
+		if attempt >= rc.config.MaxRetries {
+			break
+		}
+		delay, ok := rc.backoff(attempt, err)
+		if !ok {
+			// The provider asked for a longer wait than callers are made to sit through
+			break
+		}
+		if sleepContext(ctx, delay) != nil {
+			if !attemptTimedOut(ctx) {
+				rc.release()
+				return nil, lastErr
+			}
+			break
+		}
+	}
+
+	rc.recordFailure()
+	return nil, fmt.Errorf("%w: %v", derrors.ErrLLMDegraded, lastErr)
+}
+
+// attemptTimedOut reports whether ctx ended on the router's per-provider deadline rather
+// than being cancelled by the caller
+func attemptTimedOut(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errAttemptTimeout)
+}
+
+// acquire takes a concurrency slot, waiting until one is free or ctx ends
+func (rc *ResilientClient) acquire(ctx context.Context) bool {
+	if rc.slots == nil {
+		return true
+	}
+	select {
+	case rc.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// free returns the slot taken by acquire
+func (rc *ResilientClient) free() {
+	if rc.slots != nil {
+		<-rc.slots
+	}
+}
+
+// State returns the circuit breaker state
+func (rc *ResilientClient) State() string {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.state == CircuitOpen && time.Since(rc.openedAt) >= rc.config.OpenDuration {
+		return CircuitHalfOpen
+	}
+	return rc.state
+}
+
+// Degraded reports whether calls are currently being refused
+func (rc *ResilientClient) Degraded() bool {
+	return rc.State() == CircuitOpen
+}
+
+// backoff returns the wait before the next attempt: the provider's Retry-After when
+// given, otherwise exponential backoff with jitter between half and the full delay.
+// It reports false when Retry-After is longer than MaxDelay, so the call gives up.
+func (rc *ResilientClient) backoff(attempt int, err error) (time.Duration, bool) {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) && providerErr.RetryAfter > 0 {
+		if rc.config.MaxDelay > 0 && providerErr.RetryAfter > rc.config.MaxDelay {
+			return 0, false
+		}
+		return providerErr.RetryAfter, true
+	}
+	delay := rc.config.BaseDelay << attempt
+	if rc.config.MaxDelay > 0 && (delay > rc.config.MaxDelay || delay <= 0) {
+		delay = rc.config.MaxDelay
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1)), true
+}
+
+// allow reports whether a call may go out. After OpenDuration a single probe is let
+// through (half-open); its outcome closes or re-opens the circuit.
+func (rc *ResilientClient) allow() bool {
+	if rc.config.FailureThreshold <= 0 {
+		return true
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	switch rc.state {
+	case CircuitOpen:
+		if time.Since(rc.openedAt) < rc.config.OpenDuration {
+			return false
+		}
+		rc.state = CircuitHalfOpen
+		rc.probeActive = true
+		return true
+	case CircuitHalfOpen:
+		if rc.probeActive {
+			return false
+		}
+		rc.probeActive = true
+		return true
+	default:
+		return true
+	}
+}
+
+// release frees the half-open probe slot when the probe ended without a verdict
+func (rc *ResilientClient) release() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.probeActive = false
+}
+
+func (rc *ResilientClient) recordSuccess() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.state != CircuitClosed {
+		log.Printf("✅ LLM circuit closed, provider %s recovered", rc.name)
+	}
+	rc.state = CircuitClosed
+	rc.failures = 0
+	rc.probeActive = false
+}
+
+func (rc *ResilientClient) recordFailure() {
+	if rc.config.FailureThreshold <= 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.failures++
+	rc.probeActive = false
+	if rc.state == CircuitHalfOpen || rc.failures >= rc.config.FailureThreshold {
+		if rc.state != CircuitOpen {
+			log.Printf("⚠️ LLM circuit open for %s after %d failed calls to provider %s", rc.config.OpenDuration, rc.failures, rc.name)
+		}
+		rc.state = CircuitOpen
+		rc.openedAt = time.Now()
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,212 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+// ProviderRouter is the provider recorded for calls no provider in the chain served
+const ProviderRouter = "router"
+
+// routedProvider is one entry of the fallback chain with its health counters
+type routedProvider struct {
+	config dto.LLMConfig
+	// client is nil when the provider could not be set up; reason says why
+	client  *ResilientClient
+	reason  string
+	mu      sync.Mutex
+	calls   int64
+	fails   int64
+	streak  int64
+	latency time.Duration
+	lastErr string
+	errAt   time.Time
+	okAt    time.Time
+}
+
+// RouterClient sends each call to the first healthy provider in a configured order and
+// fails over to the next one on an error or timeout. Every provider has its own
+// ResilientClient, so retries and the circuit breaker are per provider; a provider whose
+// circuit is open is skipped until its cooldown ends. Replies are stamped with the
+// provider that served them.
+type RouterClient struct {
+	providers []*routedProvider
+	config    dto.LLMRouterConfig
+}
+
+// NewRouterClient builds the chain in order. Providers that cannot be created (a missing
+// API key or model, an unknown provider) are kept as unavailable so they show up in
+// ProviderStatus; with none available every call fails with AppError.ErrLLMDegraded.
+func NewRouterClient(configs []dto.LLMConfig, resilience dto.LLMResilienceConfig, config dto.LLMRouterConfig) *RouterClient {
+	rc := &RouterClient{config: config}
+	for _, cfg := range configs {
+		if cfg.Name == "" {
+			cfg.Name = cfg.Provider + "/" + cfg.Model
+		}
+		p := &routedProvider{config: cfg}
+		if client, err := NewClient(cfg); err != nil {
+			p.reason = err.Error()
+		} else {
+			p.client = NewResilientClient(client, resilience)
+			p.client.name = cfg.Name
+		}
+		rc.providers = append(rc.providers, p)
+	}
+	return rc
+}
+
+var _ interfaces.LLMClient = (*RouterClient)(nil)
+var _ interfaces.LLMProviderStatusProvider = (*RouterClient)(nil)
+
+// Generate tries the providers in order until one answers
+func (rc *RouterClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	var lastErr error
+	available, tried := 0, 0
+	for _, p := range rc.providers {
+		if p.client == nil {
+			continue
+		}
+		available++
+		if p.client.Degraded() {
+			continue
+		}
+		tried++
+
+		resp, err := rc.attempt(ctx, p, req)
+		if err == nil {
+			resp.Provider = p.config.Name
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			// The caller gave up; another provider would not help
+			return nil, err
+		}
+		lastErr = err
+		log.Printf("⚠️ LLM provider %s failed, trying the next one: %v", p.config.Name, err)
+	}
+
+	if available == 0 {
+		return nil, fmt.Errorf("%w: no LLM provider available", derrors.ErrLLMDegraded)
+	}
+	if tried == 0 {
+		return nil, fmt.Errorf("%w: circuit open for all %d LLM providers", derrors.ErrLLMDegraded, available)
+	}
+	if errors.Is(lastErr, derrors.ErrLLMDegraded) {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("%w: all %d LLM providers failed: %v", derrors.ErrLLMDegraded, tried, lastErr)
+}
+
+// errAttemptTimeout ends a provider's attempt that ran past AttemptTimeout. Unlike the caller
+// giving up, it counts as a failure of the provider, so one that keeps timing out has its
+// circuit opened instead of delaying every call by the full timeout.
+var errAttemptTimeout = errors.New("LLM provider attempt timed out")
+
+// attempt calls one provider within the failover timeout and records the outcome
+func (rc *RouterClient) attempt(ctx context.Context, p *routedProvider, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	attemptCtx := ctx
+	if rc.config.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeoutCause(ctx, rc.config.AttemptTimeout, errAttemptTimeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	resp, err := p.client.Generate(attemptCtx, req)
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+		err = fmt.Errorf("no reply within %s: %w", rc.config.AttemptTimeout, err)
+	}
+	if ctx.Err() == nil {
+		p.record(time.Since(started), err)
+	}
+	return resp, err
+}
+
+// ProviderStatus reports every provider in chain order
+func (rc *RouterClient) ProviderStatus() []dto.LLMProviderStatus {
+	statuses := make([]dto.LLMProviderStatus, 0, len(rc.providers))
+	for i, p := range rc.providers {
+		statuses = append(statuses, p.status(i+1))
+	}
+	return statuses
+}
+
+// Name joins the provider names in chain order; the response cache is keyed on it, so
+// replies cached for one chain are not served after the chain changes
+func (rc *RouterClient) Name() string {
+	names := make([]string, len(rc.providers))
+	for i, p := range rc.providers {
+		names[i] = p.config.Name
+	}
+	return strings.Join(names, ",")
+}
+
+// Live returns the names of the providers currently taking calls
+func (rc *RouterClient) Live() []string {
+	var live []string
+	for _, p := range rc.providers {
+		if p.client != nil && !p.client.Degraded() {
+			live = append(live, p.config.Name)
+		}
+	}
+	return live
+}
+
+func (p *routedProvider) record(latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	p.latency += latency
+	if err != nil {
+		p.fails++
+		p.streak++
+		p.lastErr = err.Error()
+		p.errAt = time.Now().UTC()
+		return
+	}
+	p.streak = 0
+	p.okAt = time.Now().UTC()
+}
+
+func (p *routedProvider) status(priority int) dto.LLMProviderStatus {
+	status := dto.LLMProviderStatus{
+		Name:      p.config.Name,
+		Provider:  p.config.Provider,
+		Model:     p.config.Model,
+		Priority:  priority,
+		Available: p.client != nil,
+		Reason:    p.reason,
+	}
+	if p.client != nil {
+		status.Circuit = p.client.State()
+		status.Live = status.Circuit != CircuitOpen
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status.Calls = p.calls
+	status.Failures = p.fails
+	status.ConsecutiveFailures = p.streak
+	if p.calls > 0 {
+		status.AvgLatencyMs = float64(p.latency.Milliseconds()) / float64(p.calls)
+	}
+	status.LastError = p.lastErr
+	if !p.errAt.IsZero() {
+		errAt := p.errAt
+		status.LastErrorAt = &errAt
+	}
+	if !p.okAt.IsZero() {
+		okAt := p.okAt
+		status.LastSuccessAt = &okAt
+	}
+	return status
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+// ScriptedResponse answers any prompt that contains Match with Response (or Error)
+type ScriptedResponse struct {
+	Match    string `json:"match"`
+	Response string `json:"response"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ScriptedScript is the on-disk format of a scripted client
+type ScriptedScript struct {
+	Fallback  string             `json:"fallback"`
+	Responses []ScriptedResponse `json:"responses"`
+}
+
+// ScriptedClient is an offline LLMClient that returns canned responses.
+// It is meant for evaluation runs and tests where no real provider is available.
+type ScriptedClient struct {
+	script ScriptedScript
+}
+
+// NewScriptedClient creates a client that returns the first matching scripted response,
+// or the fallback response when nothing matches
+func NewScriptedClient(responses []ScriptedResponse, fallback string) interfaces.LLMClient {
+	return &ScriptedClient{script: ScriptedScript{Fallback: fallback, Responses: responses}}
+}
+
+// LoadScriptedClient reads a ScriptedScript JSON file
+func LoadScriptedClient(path string) (interfaces.LLMClient, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script file: %w", err)
+	}
+	var script ScriptedScript
+	if err := json.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("failed to parse script file: %w", err)
+	}
+	return &ScriptedClient{script: script}, nil
+}
+
+// Generate returns the scripted response whose Match appears in the system prompt or messages
+func (s *ScriptedClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	prompt := promptText(req)
+	for _, r := range s.script.Responses {
+		if r.Match != "" && strings.Contains(prompt, r.Match) {
+			if r.Error != "" {
+				return nil, errors.New(r.Error)
+			}
+			return &dto.GenerateResponse{Text: r.Response, Model: "scripted"}, nil
+		}
+	}
+	if s.script.Fallback == "" {
+		return nil, fmt.Errorf("no scripted response matches the prompt")
+	}
+	return &dto.GenerateResponse{Text: s.script.Fallback, Model: "scripted"}, nil
+}
+
+// promptText joins the system prompt and messages for matching
+func promptText(req dto.GenerateRequest) string {
+	parts := make([]string, 0, len(req.Messages)+1)
+	parts = append(parts, req.System)
+	for _, m := range req.Messages {
+		parts = append(parts, m.Content)
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+)
+
+// usageDateLayout is the UTC day a call is accounted to
+const usageDateLayout = "2006-01-02"
+
+// UsageClient records token usage, latency, the calling feature and prompt version for every provider
+// call, and refuses calls with AppError.ErrLLMDegraded once the daily token budget is spent.
+type UsageClient struct {
+	inner    interfaces.LLMClient
+	repo     interfaces.LLMUsageRepository
+	config   dto.LLMBudgetConfig
+	provider string
+	model    string
+
+	mu      sync.Mutex
+	day     string
+	used    int64
+	refused int64
+}
+
+// NewUsageClient wraps inner with usage accounting; repo may be nil to only enforce the budget
+func NewUsageClient(inner interfaces.LLMClient, repo interfaces.LLMUsageRepository, config dto.LLMBudgetConfig, provider, model string) *UsageClient {
+	return &UsageClient{
+		inner:    inner,
+		repo:     repo,
+		config:   config,
+		provider: provider,
+		model:    model,
+	}
+}
+
+var _ interfaces.LLMClient = (*UsageClient)(nil)
+var _ interfaces.LLMBudgetProvider = (*UsageClient)(nil)
+
+// Restore loads today's token count so a restart does not reset the budget
+func (uc *UsageClient) Restore(ctx context.Context) error {
+	if uc.repo == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	used, err := uc.repo.TotalTokensSince(ctx, startOfDay)
+	if err != nil {
+		return fmt.Errorf("failed to load today's LLM usage: %w", err)
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.day = now.Format(usageDateLayout)
+	uc.used = used
+	return nil
+}
+
+// Generate calls the wrapped client unless the budget is spent, and records the call
+func (uc *UsageClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	if uc.exceeded() {
+		return nil, fmt.Errorf("%w: daily LLM token budget of %d exhausted", derrors.ErrLLMDegraded, uc.config.DailyTokens)
+	}
+
+	started := time.Now()
+	resp, err := uc.inner.Generate(ctx, req)
+
+	record := &entities.LLMUsageRecord{
+		Feature:       req.Feature,
+		PromptVersion: req.PromptVersion,
+		Provider:      uc.provider,
+		Model:         uc.model,
+		LatencyMs:     time.Since(started).Milliseconds(),
+		Success:       err == nil,
+		CreatedAt:     started.UTC(),
+	}
+	if record.Feature == "" {
+		record.Feature = "other"
+	}
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		if resp.Provider != "" {
+			record.Provider = resp.Provider
+		}
+		if resp.Model != "" {
+			record.Model = resp.Model
+		}
+		record.PromptTokens = resp.Usage.PromptTokens
+		record.CompletionTokens = resp.Usage.CompletionTokens
+		record.TotalTokens = resp.Usage.TotalTokens
+		if record.TotalTokens == 0 {
+			record.TotalTokens = record.PromptTokens + record.CompletionTokens
+		}
+	}
+	uc.add(int64(record.TotalTokens))
+
+	if uc.repo != nil {
+		// Keep the record even when the caller has already gone away
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		if rerr := uc.repo.Create(recordCtx, record); rerr != nil {
+			log.Printf("❌ Failed to record LLM usage: %v", rerr)
+		}
+		cancel()
+	}
+
+	return resp, err
+}
+
+// BudgetStatus returns today's token use against the daily budget
+func (uc *UsageClient) BudgetStatus() dto.LLMBudgetStatus {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.rollover()
+	status := dto.LLMBudgetStatus{
+		Date:         uc.day,
+		DailyTokens:  uc.config.DailyTokens,
+		UsedTokens:   uc.used,
+		RefusedCalls: uc.refused,
+	}
+	if uc.config.DailyTokens > 0 {
+		status.Exceeded = uc.used >= uc.config.DailyTokens
+		if !status.Exceeded {
+			status.Remaining = uc.config.DailyTokens - uc.used
+		}
+	}
+	return status
+}
+
+func (uc *UsageClient) exceeded() bool {
+	if uc.config.DailyTokens <= 0 {
+		return false
+	}
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.rollover()
+	if uc.used < uc.config.DailyTokens {
+		return false
+	}
+	uc.refused++
+	return true
+}
+
+func (uc *UsageClient) add(tokens int64) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.rollover()
+	before := uc.used
+	uc.used += tokens
+	if limit := uc.config.DailyTokens; limit > 0 && before < limit && uc.used >= limit {
+		log.Printf("⚠️ Daily LLM token budget of %d reached; LLM features are degraded until midnight UTC", limit)
+	}
+}
+
+// rollover starts a new budget day at midnight UTC; callers hold mu
+func (uc *UsageClient) rollover() {
+	today := time.Now().UTC().Format(usageDateLayout)
+	if uc.day != today {
+		uc.day = today
+		uc.used = 0
+		uc.refused = 0
+	}
+}
@@ -0,0 +1,89 @@
+// Package llminput prepares user-supplied text for LLM prompts. Prompt templates
+// fence user text so the model can tell it apart from instructions, and Detect
+// flags text that looks like an attempt to override those instructions.
+package llminput
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Fence tags; prompts tell the model that text between them is data, never instructions
+const (
+	OpenTag  = "<user_input>"
+	CloseTag = "</user_input>"
+)
+
+// Fence escapes text and wraps it in the user_input tags, each on its own line
+func Fence(text string) string {
+	return OpenTag + "\n" + Escape(text) + "\n" + CloseTag
+}
+
+// angleEscaper keeps user text from opening or closing a fence of its own
+var angleEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+
+// Escape drops control and invisible formatting characters (newlines and tabs are
+// kept) and escapes angle brackets
+func Escape(text string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r == '\r' || unicode.IsControl(r) || hiddenRune(r) {
+			return -1
+		}
+		return r
+	}, text)
+	return angleEscaper.Replace(cleaned)
+}
+
+// hiddenRune reports zero-width and bidi override characters that can hide text from
+// a human reviewer. Zero-width joiners are kept as some scripts need them.
+func hiddenRune(r rune) bool {
+	switch {
+	case r == '\u200b', r == '\u2060', r == '\ufeff':
+		return true
+	case r >= '\u202a' && r <= '\u202e', r >= '\u2066' && r <= '\u2069':
+		return true
+	}
+	return false
+}
+
+// injectionPattern is a named heuristic for text that addresses the model rather than describing symptoms
+type injectionPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// injectionPatterns run on lowercased text with whitespace collapsed. They err on the
+// side of flagging: a false positive only makes triage more cautious.
+var injectionPatterns = []injectionPattern{
+	{"ignore_instructions", regexp.MustCompile(`\b(ignore|disregard|forget|override|bypass|skip)\b.{0,40}\b(instructions?|rules?|prompts?|directions?|guidelines?|context)\b`)},
+	{"role_override", regexp.MustCompile(`\b(you are now|act as|pretend (to be|you are)|from now on,? you|new instructions|developer mode|jailbreak)\b`)},
+	{"system_prompt", regexp.MustCompile(`\b(system prompt|system message|your (instructions|prompt|rules))\b`)},
+	{"forced_output", regexp.MustCompile(`\b(output|respond with|reply with|return|answer with|classify (this|it|me) as|set (the )?level to|mark (this|it|me) as)\b.{0,20}\b(green|unclear|json)\b`)},
+	{"json_injection", regexp.MustCompile(`"(level|flags|matches|topic_key|valid|urgency_level|rule_id)"\s*:`)},
+	{"fence_tag", regexp.MustCompile(`</?\s*user_input|` + "```" + `|\b(system|assistant)\s*:`)},
+	// Amharic: "ignore/forget ... instruction(s)"
+	{"ignore_instructions_am", regexp.MustCompile(`(ችላ|እርሳ|ተው).{0,40}(መመሪያ|ትዕዛዝ)|(መመሪያ|ትዕዛዝ).{0,40}(ችላ|እርሳ|ተው)`)},
+}
+
+// Detect returns the names of the injection heuristics text matches, or nil
+func Detect(text string) []string {
+	normalized := strings.Map(func(r rune) rune {
+		if hiddenRune(r) {
+			return -1
+		}
+		return r
+	}, strings.ToLower(text))
+	normalized = strings.Join(strings.Fields(normalized), " ")
+
+	var matched []string
+	for _, p := range injectionPatterns {
+		if p.re.MatchString(normalized) {
+			matched = append(matched, p.name)
+		}
+	}
+	return matched
+}
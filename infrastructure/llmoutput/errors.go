@@ -0,0 +1,43 @@
+package llmoutput
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Failure kinds; use errors.Is to tell them apart
+var (
+	// ErrMalformed means no usable JSON could be extracted, even after repair
+	ErrMalformed = errors.New("malformed LLM output")
+	// ErrSchemaViolation means the JSON was valid but not the shape we asked for
+	ErrSchemaViolation = errors.New("LLM output violates expected schema")
+	// ErrRefusal means the model declined to answer instead of returning JSON
+	ErrRefusal = errors.New("LLM refused to answer")
+)
+
+// ParseError describes why an LLM response could not be used
+type ParseError struct {
+	Kind   error  // ErrMalformed, ErrSchemaViolation or ErrRefusal
+	Field  string // offending field for schema violations, if known
+	Detail string
+	Raw    string // the original response, for logs and audits
+}
+
+func (e *ParseError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%v: %s: %s", e.Kind, e.Field, e.Detail)
+	}
+	return fmt.Sprintf("%v: %s", e.Kind, e.Detail)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
+
+func malformed(raw, format string, args ...interface{}) *ParseError {
+	return &ParseError{Kind: ErrMalformed, Detail: fmt.Sprintf(format, args...), Raw: raw}
+}
+
+func schemaViolation(raw, field, format string, args ...interface{}) *ParseError {
+	return &ParseError{Kind: ErrSchemaViolation, Field: field, Detail: fmt.Sprintf(format, args...), Raw: raw}
+}
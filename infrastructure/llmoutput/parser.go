@@ -0,0 +1,245 @@
+// Package llmoutput turns free-form LLM responses into validated Go values.
+// Every LLM consumer should go through Decode so that markdown fences, chatty
+// preambles, truncated output and refusals are handled the same way everywhere.
+package llmoutput
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Decode extracts the first JSON value matching target's kind (array for slices,
+// object otherwise) from raw, repairs common truncation, checks it against schema
+// and unmarshals it into target. Failures are *ParseError values wrapping
+// ErrMalformed, ErrSchemaViolation or ErrRefusal.
+func Decode(raw string, target interface{}, schema Schema) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("llmoutput: target must be a non-nil pointer")
+	}
+
+	opener := byte('{')
+	if k := rv.Elem().Kind(); k == reflect.Slice || k == reflect.Array {
+		opener = '['
+	}
+
+	jsonText, err := Extract(raw, opener)
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal([]byte(jsonText), &generic); err != nil {
+		return malformed(raw, "invalid JSON: %v", err)
+	}
+	if err := schema.validate(raw, generic); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(jsonText), target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return schemaViolation(raw, typeErr.Field, "expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+		return malformed(raw, "failed to decode JSON: %v", err)
+	}
+	return nil
+}
+
+// Extract returns the JSON text of the first complete value starting with opener
+// ('{' or '['). If the response was cut off, the value is repaired by closing it
+// after the last complete element.
+func Extract(raw string, opener byte) (string, error) {
+	text := stripFences(raw)
+	if text == "" {
+		return "", malformed(raw, "empty response")
+	}
+
+	first := strings.IndexByte(text, opener)
+	if first == -1 {
+		if looksLikeRefusal(text) {
+			return "", &ParseError{Kind: ErrRefusal, Detail: truncateForError(text), Raw: raw}
+		}
+		return "", malformed(raw, "no JSON %s found", kindName(opener))
+	}
+
+	// Prefer a complete value; skip openers that only appear in prose
+	for start := first; start != -1; {
+		scan := scanValue(text[start:])
+		if scan.complete {
+			candidate := text[start : start+scan.end]
+			if json.Valid([]byte(candidate)) {
+				return candidate, nil
+			}
+		}
+		next := strings.IndexByte(text[start+1:], opener)
+		if next == -1 {
+			break
+		}
+		start += next + 1
+	}
+
+	if repaired, ok := repairTruncated(text[first:]); ok {
+		return repaired, nil
+	}
+	return "", malformed(raw, "incomplete JSON %s could not be repaired", kindName(opener))
+}
+
+// stripFences removes markdown code fences such as ```json ... ```
+func stripFences(raw string) string {
+	text := strings.TrimSpace(raw)
+	text = strings.ReplaceAll(text, "```json", "")
+	text = strings.ReplaceAll(text, "```JSON", "")
+	text = strings.ReplaceAll(text, "```", "")
+	return strings.TrimSpace(text)
+}
+
+// cutPoint is a position right after a complete element, with the containers still open there
+type cutPoint struct {
+	pos   int
+	stack []byte
+}
+
+type scanResult struct {
+	complete bool
+	end      int
+	inString bool
+	stack    []byte // closers still expected, innermost last
+	cuts     []cutPoint
+}
+
+// scanValue walks a JSON value that starts at s[0], tracking nesting and string state.
+// JSON structural characters are ASCII, so scanning bytes is safe for UTF-8 text.
+func scanValue(s string) scanResult {
+	var res scanResult
+	inString, escaped := false, false
+	var lastSig byte       // last non-space byte outside strings
+	var stringIsValue bool // the open string is a value rather than an object key
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+				lastSig = '"'
+				if stringIsValue && len(res.stack) > 0 {
+					res.cuts = append(res.cuts, cutPoint{pos: i + 1, stack: copyStack(res.stack)})
+				}
+			}
+			continue
+		}
+
+		switch c {
+		case ' ', '\n', '\r', '\t':
+			continue
+		case '"':
+			inString = true
+			top := byte(0)
+			if len(res.stack) > 0 {
+				top = res.stack[len(res.stack)-1]
+			}
+			stringIsValue = top == ']' || lastSig == ':'
+		case '{':
+			res.stack = append(res.stack, '}')
+		case '[':
+			res.stack = append(res.stack, ']')
+		case '}', ']':
+			if len(res.stack) == 0 || res.stack[len(res.stack)-1] != c {
+				// Unbalanced: not a value we can use
+				res.stack = nil
+				return res
+			}
+			res.stack = res.stack[:len(res.stack)-1]
+			if len(res.stack) == 0 {
+				res.complete = true
+				res.end = i + 1
+				return res
+			}
+			res.cuts = append(res.cuts, cutPoint{pos: i + 1, stack: copyStack(res.stack)})
+		}
+		lastSig = c
+	}
+
+	res.inString = inString
+	return res
+}
+
+// repairTruncated closes a value that was cut off. It prefers dropping everything
+// after the last complete element, so a half-written item is never returned, and
+// otherwise closes whatever is still open.
+func repairTruncated(s string) (string, bool) {
+	scan := scanValue(s)
+	if scan.complete || len(scan.stack) == 0 {
+		return "", false
+	}
+
+	for i := len(scan.cuts) - 1; i >= 0; i-- {
+		cut := scan.cuts[i]
+		if candidate := closeValue(s[:cut.pos], cut.stack); json.Valid([]byte(candidate)) {
+			return candidate, true
+		}
+	}
+
+	text := s
+	if scan.inString {
+		text += `"`
+	}
+	if candidate := closeValue(text, scan.stack); json.Valid([]byte(candidate)) {
+		return candidate, true
+	}
+	return "", false
+}
+
+// closeValue drops a dangling separator and appends the missing closers
+func closeValue(s string, stack []byte) string {
+	s = strings.TrimRight(s, " \n\r\t")
+	s = strings.TrimSuffix(s, ",")
+	var b strings.Builder
+	b.WriteString(s)
+	for i := len(stack) - 1; i >= 0; i-- {
+		b.WriteByte(stack[i])
+	}
+	return b.String()
+}
+
+func copyStack(stack []byte) []byte {
+	return append([]byte(nil), stack...)
+}
+
+var refusalPhrases = []string{
+	"i'm sorry", "i am sorry", "i cannot", "i can't", "i can not", "i'm unable", "i am unable",
+	"i won't", "i will not", "as an ai", "not able to provide", "cannot help with", "ይቅርታ",
+}
+
+// looksLikeRefusal reports whether a response without JSON reads like the model declining
+func looksLikeRefusal(text string) bool {
+	lower := strings.ToLower(strings.ReplaceAll(text, "’", "'"))
+	for _, phrase := range refusalPhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+func kindName(opener byte) string {
+	if opener == '[' {
+		return "array"
+	}
+	return "object"
+}
+
+func truncateForError(s string) string {
+	const max = 200
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
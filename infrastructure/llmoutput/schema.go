@@ -0,0 +1,78 @@
+package llmoutput
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema we need for LLM responses. For arrays,
+// Required and Enum apply to every object item.
+type Schema struct {
+	// Required fields must be present, non-null and, for strings, non-empty
+	Required []string
+	// Enum restricts string fields to the listed values
+	Enum map[string][]string
+	// MinItems is the minimum array length; ignored for objects
+	MinItems int
+}
+
+func (s Schema) validate(raw string, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return s.validateObject(raw, "", v)
+	case []interface{}:
+		if len(v) < s.MinItems {
+			return schemaViolation(raw, "", "got %d items, need at least %d", len(v), s.MinItems)
+		}
+		for i, item := range v {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				if len(s.Required) > 0 || len(s.Enum) > 0 {
+					return schemaViolation(raw, fmt.Sprintf("[%d]", i), "expected an object")
+				}
+				continue
+			}
+			if err := s.validateObject(raw, fmt.Sprintf("[%d].", i), obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return schemaViolation(raw, "", "expected an object or array")
+	}
+}
+
+func (s Schema) validateObject(raw, prefix string, obj map[string]interface{}) error {
+	for _, field := range s.Required {
+		value, ok := obj[field]
+		if !ok || value == nil {
+			return schemaViolation(raw, prefix+field, "required field missing")
+		}
+		if str, isString := value.(string); isString && strings.TrimSpace(str) == "" {
+			return schemaViolation(raw, prefix+field, "required field empty")
+		}
+	}
+	for field, allowed := range s.Enum {
+		value, ok := obj[field]
+		if !ok || value == nil {
+			continue
+		}
+		str, isString := value.(string)
+		if !isString {
+			return schemaViolation(raw, prefix+field, "expected a string")
+		}
+		if !contains(allowed, str) {
+			return schemaViolation(raw, prefix+field, "%q is not one of %s", str, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+// Package prompts renders the LLM prompts from versioned text/template files in
+// data/prompts, or from versions stored in Mongo, so prompts can change without a code release.
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/llminput"
+)
+
+// promptFields lists the prompts the services render and the data each template receives
+var promptFields = map[string][]string{
+	dto.LLMFeatureTriage:            {"RedFlags", "YellowFlags", "Topics", "Patient", "Language", "Input"},
+	dto.LLMFeatureMapTopic:          {"NoFit", "Topics", "Symptom"},
+	dto.LLMFeatureValidateSymptom:   {"Symptom", "Language"},
+	dto.LLMFeatureGenerateQuestions: {"Symptom", "Language"},
+	dto.LLMFeatureValidateAnswer:    {"Question", "QuestionType", "Answer"},
+	dto.LLMFeatureReport:            {"Conversation"},
+}
+
+// Template blocks; "system" is optional
+const (
+	blockSystem = "system"
+	blockUser   = "user"
+)
+
+// templateFuncs are available to every template; user text goes through fence
+var templateFuncs = template.FuncMap{
+	"quote": strconv.Quote,
+	"fence": llminput.Fence,
+}
+
+type compiledPrompt struct {
+	name    string
+	version int
+	tmpl    *template.Template
+}
+
+// Registry holds the active version of every prompt
+type Registry struct {
+	dir  string
+	repo interfaces.PromptTemplateRepository // nil uses the files only
+
+	mu     sync.RWMutex
+	active map[string]*compiledPrompt
+}
+
+// NewRegistry loads the active prompt versions. Every prompt needs a template, so a
+// missing or invalid one is an error rather than an LLM call with a broken prompt.
+func NewRegistry(dir string, repo interfaces.PromptTemplateRepository) (*Registry, error) {
+	r := &Registry{dir: dir, repo: repo}
+	if err := r.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+var _ interfaces.PromptRegistry = (*Registry)(nil)
+
+// LoadTemplateFiles reads every <dir>/<name>/v<version>.tmpl, sorted by name and version
+func LoadTemplateFiles(dir string) ([]entities.PromptTemplate, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", "v*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no prompt templates found in %s", dir)
+	}
+
+	templates := make([]entities.PromptTemplate, 0, len(files))
+	for _, file := range files {
+		name := filepath.Base(filepath.Dir(file))
+		if _, ok := promptFields[name]; !ok {
+			return nil, fmt.Errorf("unknown prompt %q in %s", name, file)
+		}
+		version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "v"), ".tmpl"))
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("prompt template file %s must be named v<version>.tmpl", file)
+		}
+		if version >= entities.AdminPromptVersionBase {
+			return nil, fmt.Errorf("prompt template file %s: versions from %d on are for versions created by admins", file, entities.AdminPromptVersionBase)
+		}
+		body, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		templates = append(templates, entities.PromptTemplate{
+			ID:      entities.PromptVersionRef(name, version),
+			Name:    name,
+			Version: version,
+			Body:    string(body),
+			Source:  entities.PromptSourceFile,
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].Version < templates[j].Version
+	})
+	return templates, nil
+}
+
+// Refresh reloads the active versions. A version activated in Mongo wins; a prompt
+// without one uses its newest file. On error the loaded templates stay in use.
+func (r *Registry) Refresh(ctx context.Context) error {
+	files, err := LoadTemplateFiles(r.dir)
+	if err != nil {
+		return err
+	}
+	candidates := make(map[string]entities.PromptTemplate, len(promptFields))
+	for _, t := range files {
+		candidates[t.Name] = t
+	}
+	if r.repo != nil {
+		stored, err := r.repo.List(ctx, "")
+		if err != nil {
+			return fmt.Errorf("failed to load prompt templates: %w", err)
+		}
+		for _, t := range stored {
+			if t.Active {
+				candidates[t.Name] = t
+			}
+		}
+	}
+
+	active := make(map[string]*compiledPrompt, len(promptFields))
+	for name := range promptFields {
+		t, ok := candidates[name]
+		if !ok {
+			return fmt.Errorf("no template for prompt %q in %s", name, r.dir)
+		}
+		tmpl, err := parse(name, t.Body)
+		if err != nil {
+			return fmt.Errorf("prompt %s: %w", entities.PromptVersionRef(name, t.Version), err)
+		}
+		active[name] = &compiledPrompt{name: name, version: t.Version, tmpl: tmpl}
+	}
+
+	r.mu.Lock()
+	r.active = active
+	r.mu.Unlock()
+	return nil
+}
+
+// StartRefresh reloads the templates every interval so versions activated on another instance go live here too
+func (r *Registry) StartRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.Refresh(ctx); err != nil {
+					log.Printf("❌ Failed to reload prompt templates: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Render executes the active version of a prompt. data must hold every field the template uses.
+func (r *Registry) Render(name string, data map[string]string) (*dto.RenderedPrompt, error) {
+	r.mu.RLock()
+	prompt := r.active[name]
+	r.mu.RUnlock()
+	if prompt == nil {
+		return nil, fmt.Errorf("%w: %s", derrors.ErrPromptTemplateNotFound, name)
+	}
+
+	rendered := &dto.RenderedPrompt{
+		Name:    name,
+		Version: prompt.version,
+		Ref:     entities.PromptVersionRef(name, prompt.version),
+	}
+	var out strings.Builder
+	if prompt.tmpl.Lookup(blockSystem) != nil {
+		if err := prompt.tmpl.ExecuteTemplate(&out, blockSystem, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", rendered.Ref, err)
+		}
+		rendered.System = out.String()
+		out.Reset()
+	}
+	if err := prompt.tmpl.ExecuteTemplate(&out, blockUser, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", rendered.Ref, err)
+	}
+	rendered.User = out.String()
+	return rendered, nil
+}
+
+// Names returns the prompt names in order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(promptFields))
+	for name := range promptFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fields returns the data fields a prompt's template can use
+func (r *Registry) Fields(name string) []string {
+	return append([]string(nil), promptFields[name]...)
+}
+
+// ActiveVersion returns the version of a prompt currently in use, or 0 for an unknown prompt
+func (r *Registry) ActiveVersion(name string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if prompt := r.active[name]; prompt != nil {
+		return prompt.version
+	}
+	return 0
+}
+
+// Validate checks that body parses and renders for the prompt without using unknown fields
+func (r *Registry) Validate(name, body string) error {
+	if _, ok := promptFields[name]; !ok {
+		return fmt.Errorf("%w: %s", derrors.ErrPromptTemplateNotFound, name)
+	}
+	if _, err := parse(name, body); err != nil {
+		return fmt.Errorf("%w: %v", derrors.ErrInvalidPromptTemplate, err)
+	}
+	return nil
+}
+
+// parse compiles a template body and renders it once with placeholder data, so a
+// misspelt field fails here instead of on a live request
+func parse(name, body string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Lookup(blockUser) == nil {
+		return nil, errors.New(`template must define a "user" block`)
+	}
+
+	sample := make(map[string]string, len(promptFields[name]))
+	for _, field := range promptFields[name] {
+		sample[field] = "example"
+	}
+	for _, block := range []string{blockSystem, blockUser} {
+		if tmpl.Lookup(block) == nil {
+			continue
+		}
+		if err := tmpl.ExecuteTemplate(io.Discard, block, sample); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
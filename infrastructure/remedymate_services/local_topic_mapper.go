@@ -0,0 +1,132 @@
+package remedymate_services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/lexical"
+	"remedymate-backend/util/lang"
+)
+
+// Field weights: a topic's names and synonyms say more about it than its self-care advice
+const (
+	topicNameWeight        = 3
+	topicSynonymWeight     = 3
+	topicDescriptionWeight = 2
+	topicBodyWeight        = 1
+)
+
+// LocalTopicMapper ranks topics for a symptom description with BM25 over the approved
+// content in every language, without an LLM call. The index is rebuilt when the content
+// service reports new content.
+type LocalTopicMapper struct {
+	contentService interfaces.ContentService
+	config         dto.TopicMapperConfig
+
+	mu      sync.Mutex
+	index   *lexical.Index
+	version string
+}
+
+// NewLocalTopicMapper indexes the approved content and fails when there is none
+func NewLocalTopicMapper(contentService interfaces.ContentService, config dto.TopicMapperConfig) (*LocalTopicMapper, error) {
+	m := &LocalTopicMapper{contentService: contentService, config: config}
+	if _, err := m.currentIndex(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// currentIndex returns the index for the content currently served, rebuilding it after a change
+func (m *LocalTopicMapper) currentIndex() (*lexical.Index, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	version := m.contentService.ContentVersion()
+	if m.index != nil && version == m.version {
+		return m.index, nil
+	}
+
+	blocks, err := m.contentService.GetApprovedBlocks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topics for the local mapper: %w", err)
+	}
+	m.index = buildTopicIndex(blocks)
+	m.version = version
+	return m.index, nil
+}
+
+// buildTopicIndex indexes the topic names, descriptions, synonyms, self-care advice and
+// OTC categories of the approved blocks. Seek-care text is left out: it describes
+// warning signs, not the topic.
+func buildTopicIndex(blocks []entities.ApprovedBlock) *lexical.Index {
+	docs := make([]lexical.Document, 0, len(blocks))
+	for _, block := range blocks {
+		doc := lexical.Document{Key: block.TopicKey}
+		add := func(text string, weight int, label bool) {
+			if text != "" {
+				doc.Fields = append(doc.Fields, lexical.Field{Text: text, Weight: weight, Label: label})
+			}
+		}
+		if block.Names[lang.Default().DefaultCode()] == "" {
+			// A topic without a name in the default language is still found by its key, e.g. "minor_toothache"
+			add(strings.ReplaceAll(block.TopicKey, "_", " "), topicNameWeight, true)
+		}
+		for _, name := range block.Names {
+			add(name, topicNameWeight, true)
+		}
+		for _, description := range block.Descriptions {
+			add(description, topicDescriptionWeight, true)
+		}
+		for _, synonyms := range block.Synonyms {
+			for _, synonym := range synonyms {
+				add(synonym, topicSynonymWeight, true)
+			}
+		}
+		for _, content := range block.Translations {
+			for _, line := range content.SelfCare {
+				add(line, topicBodyWeight, false)
+			}
+			for _, otc := range content.OTCCategories {
+				add(otc.CategoryName, topicBodyWeight, false)
+			}
+		}
+		docs = append(docs, doc)
+	}
+
+	return lexical.NewIndex(docs)
+}
+
+var _ interfaces.LocalTopicMapper = (*LocalTopicMapper)(nil)
+
+// Rank scores the available topics for the input, best first. The best candidate is
+// usable when it reaches MinScore and the input names the topic (a word from its name,
+// description or synonyms, not only from its advice), and confident when it also leads
+// the runner-up by MinMargin.
+func (m *LocalTopicMapper) Rank(input string, availableTopics []string) dto.TopicRanking {
+	index, err := m.currentIndex()
+	if err != nil {
+		log.Printf("❌ Local topic mapping unavailable: %v", err)
+		return dto.TopicRanking{}
+	}
+	matches := index.Search(input, availableTopics)
+	ranking := dto.TopicRanking{Candidates: make([]dto.TopicCandidate, 0, len(matches))}
+	for _, match := range matches {
+		ranking.Candidates = append(ranking.Candidates, dto.TopicCandidate{TopicKey: match.Key, Score: match.Score})
+	}
+	if len(matches) == 0 || matches[0].Score < m.config.MinScore || !matches[0].Labeled {
+		return ranking
+	}
+	ranking.Usable = true
+	if len(matches) == 1 {
+		ranking.Confident = true
+		return ranking
+	}
+	lead := (matches[0].Score - matches[1].Score) / matches[0].Score
+	ranking.Confident = lead >= m.config.MinMargin
+	return ranking
+}
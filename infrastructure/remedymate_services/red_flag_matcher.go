@@ -0,0 +1,112 @@
+package remedymate_services
+
+import (
+	"strings"
+	"unicode"
+
+	"remedymate-backend/domain/entities"
+)
+
+// keywordMatch is a single rule keyword found in the user's input
+type keywordMatch struct {
+	Rule     entities.RedFlagRule
+	Keyword  string
+	Evidence string // the input text that matched, as the user wrote it
+	Start    int    // rune offsets of Evidence in the input
+	End      int
+}
+
+// matchRuleKeywords returns every rule keyword (for the given language) contained in the input.
+// Matching is case-insensitive and tolerant of repeated whitespace and typographic apostrophes.
+func matchRuleKeywords(input, lang string, rules []entities.RedFlagRule) []keywordMatch {
+	normalizedInput := normalizeWithOffsets(input)
+	if len(normalizedInput.runes) == 0 {
+		return nil
+	}
+
+	var matches []keywordMatch
+	for _, rule := range rules {
+		if rule.Language != lang {
+			continue
+		}
+		for _, keyword := range rule.Keywords {
+			start, end, ok := normalizedInput.find(keyword)
+			if !ok {
+				continue
+			}
+			matches = append(matches, keywordMatch{
+				Rule:     rule,
+				Keyword:  keyword,
+				Evidence: normalizedInput.original(start, end),
+				Start:    start,
+				End:      end,
+			})
+		}
+	}
+	return matches
+}
+
+// locateEvidence finds a quote in the input with the same tolerance as keyword matching
+func locateEvidence(input, quote string) (evidence string, start, end int, ok bool) {
+	normalizedInput := normalizeWithOffsets(input)
+	start, end, ok = normalizedInput.find(quote)
+	if !ok {
+		return "", -1, -1, false
+	}
+	return normalizedInput.original(start, end), start, end, true
+}
+
+// normalizedText is the matching form of a text (lowercased, apostrophes unified,
+// whitespace collapsed) together with, for each of its runes, the rune offset it
+// came from in the original
+type normalizedText struct {
+	source  []rune
+	runes   []rune
+	offsets []int
+}
+
+func normalizeWithOffsets(text string) normalizedText {
+	n := normalizedText{source: []rune(text)}
+	pendingSpace := -1
+	for i, r := range n.source {
+		if unicode.IsSpace(r) {
+			if len(n.runes) > 0 && pendingSpace < 0 {
+				pendingSpace = i
+			}
+			continue
+		}
+		if pendingSpace >= 0 {
+			n.runes = append(n.runes, ' ')
+			n.offsets = append(n.offsets, pendingSpace)
+			pendingSpace = -1
+		}
+		r = unicode.ToLower(r)
+		switch r {
+		case '’', '‘', '`':
+			r = '\''
+		}
+		n.runes = append(n.runes, r)
+		n.offsets = append(n.offsets, i)
+	}
+	return n
+}
+
+// find returns the original rune span of the first occurrence of needle
+func (n normalizedText) find(needle string) (start, end int, ok bool) {
+	pattern := normalizeWithOffsets(needle).runes
+	if len(pattern) == 0 {
+		return 0, 0, false
+	}
+	idx := strings.Index(string(n.runes), string(pattern))
+	if idx < 0 {
+		return 0, 0, false
+	}
+	first := len([]rune(string(n.runes)[:idx]))
+	last := first + len(pattern) - 1
+	return n.offsets[first], n.offsets[last] + 1, true
+}
+
+// original returns the input text between two rune offsets
+func (n normalizedText) original(start, end int) string {
+	return string(n.source[start:end])
+}
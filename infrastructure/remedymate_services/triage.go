@@ -2,58 +2,207 @@ package remedymate_services
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"remedymate-backend/domain/dto"
 	"remedymate-backend/domain/entities"
 	"remedymate-backend/domain/interfaces"
-	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/llminput"
+	"remedymate-backend/infrastructure/llmoutput"
+	languages "remedymate-backend/util/lang"
 )
 
 type TriageService struct {
 	contentService interfaces.ContentService
 	llmClient      interfaces.LLMClient
+	messages       interfaces.MessageCatalog
+	prompts        interfaces.PromptRenderer
+	config         dto.TriageConfig
 }
 
-func NewTriageService(contentService interfaces.ContentService, llmClient interfaces.LLMClient) interfaces.TriageService {
+func NewTriageService(contentService interfaces.ContentService, llmClient interfaces.LLMClient, messages interfaces.MessageCatalog, prompts interfaces.PromptRenderer, config dto.TriageConfig) interfaces.TriageService {
 	return &TriageService{
 		contentService: contentService,
 		llmClient:      llmClient,
+		messages:       messages,
+		prompts:        prompts,
+		config:         config,
 	}
 }
 
-// performs LLM-powered triage classification only (no fallback)
-func (ts *TriageService) ClassifySymptoms(ctx context.Context, textInput, lang string) (*entities.TriageResult, error) {
+// ClassifySymptoms runs a deterministic keyword pre-screen before the LLM.
+// A red flag keyword hit returns RED without calling the LLM, and the LLM may
+// only raise the level found by the pre-screen, never lower it. Rules with
+// patient conditions take part only when the patient meets them.
+func (ts *TriageService) ClassifySymptoms(ctx context.Context, textInput, lang string, patient *entities.PatientContext) (*entities.TriageResult, error) {
 	if err := ts.ValidateInput(textInput, lang); err != nil {
 		return nil, err
 	}
 
-	triageLevel, detectedFlags, err := ts.classifyWithLLM(ctx, textInput, lang)
+	// Use one consistent rule set for the pre-screen, the prompt and the audit trace
+	rules := ts.ruleSnapshot()
+	trace := &entities.TriageTrace{Source: entities.TriageSourceKeyword, Rules: rules}
+	applicable := rules.ForPatient(patient)
+
+	// Emergency path: must work even when the LLM is slow or unreachable
+	if redMatches := matchRuleKeywords(textInput, lang, applicable.RedRules); len(redMatches) > 0 {
+		return &entities.TriageResult{
+			Level:        entities.TriageLevelRed,
+			RedFlags:     keywordFlags(redMatches),
+			MatchedRules: keywordRuleMatches(redMatches),
+			Message:      ts.getTriageMessage(entities.TriageLevelRed, lang),
+			Trace:        trace,
+		}, nil
+	}
+
+	// Yellow keyword hits set a floor the LLM cannot go below
+	var floorLevel entities.TriageLevel
+	yellowMatches := matchRuleKeywords(textInput, lang, applicable.YellowRules)
+	if len(yellowMatches) > 0 {
+		floorLevel = entities.TriageLevelYellow
+	}
+	floorFlags := keywordFlags(yellowMatches)
+	floorMatches := keywordRuleMatches(yellowMatches)
+
+	// Text that tries to instruct the model may have talked it into a lower level, so
+	// a suspected injection never ends below YELLOW whatever the LLM answers
+	if patterns := llminput.Detect(textInput); len(patterns) > 0 {
+		log.Printf("⚠️ Possible prompt injection in triage input (language=%s, patterns=%v); flooring at YELLOW", lang, patterns)
+		trace.InjectionPatterns = patterns
+		floorLevel = entities.MoreSevere(floorLevel, entities.TriageLevelYellow)
+	}
+
+	verdict, err := ts.classifyWithConsensus(ctx, textInput, lang, patient, applicable)
+	trace.PromptHash = verdict.PromptHash
+	trace.PromptVersion = verdict.PromptVersion
+	trace.RawResponse = verdict.RawResponse
+	trace.LLMProvider = verdict.Provider
+	trace.LatencyMs = verdict.Latency.Milliseconds()
+	trace.LLMCalls = verdict.Calls
+	trace.Agreement = verdict.Agreement
+	trace.Samples = verdict.Samples
 
 	if err != nil {
+		if floorLevel != "" {
+			trace.Source = entities.TriageSourceKeywordFallback
+			trace.LLMError = err.Error()
+			return &entities.TriageResult{
+				Level:        floorLevel,
+				RedFlags:     floorFlags,
+				MatchedRules: floorMatches,
+				Message:      ts.getTriageMessage(floorLevel, lang),
+				LLMCalls:     verdict.Calls,
+				Trace:        trace,
+			}, nil
+		}
 		return nil, fmt.Errorf("triage classification failed: %w", err)
 	}
+	trace.Source = entities.TriageSourceLLM
 
 	// Handle unclear input specially
-	if len(detectedFlags) > 0 && detectedFlags[0] == "unclear_input" {
+	if verdict.Unclear {
+		if floorLevel != "" {
+			return &entities.TriageResult{
+				Level:        floorLevel,
+				RedFlags:     floorFlags,
+				MatchedRules: floorMatches,
+				Message:      ts.getTriageMessage(floorLevel, lang),
+				Agreement:    verdict.Agreement,
+				LLMCalls:     verdict.Calls,
+				Trace:        trace,
+			}, nil
+		}
 		result := &entities.TriageResult{
-			Level:    entities.TriageLevelGreen, // Use green level but with clarification message
-			RedFlags: []string{},
-			Message:  ts.getClarificationMessage(lang),
+			Level:     entities.TriageLevelGreen, // Use green level but with clarification message
+			RedFlags:  []string{},
+			Message:   ts.getClarificationMessage(lang),
+			Agreement: verdict.Agreement,
+			LLMCalls:  verdict.Calls,
+			Trace:     trace,
 		}
 		return result, nil
 	}
 
+	level := entities.MoreSevere(floorLevel, verdict.Level)
 	result := &entities.TriageResult{
-		Level:    triageLevel,
-		RedFlags: detectedFlags,
-		Message:  ts.getTriageMessage(triageLevel, lang),
+		Level:        level,
+		RedFlags:     mergeFlags(floorFlags, verdict.Flags),
+		MatchedRules: mergeRuleMatches(floorMatches, verdict.Matches),
+		Message:      ts.getTriageMessage(level, lang),
+		Agreement:    verdict.Agreement,
+		LLMCalls:     verdict.Calls,
+		Trace:        trace,
 	}
 
 	return result, nil
 }
 
+// keywordFlags returns the matched keywords as triage flags
+func keywordFlags(matches []keywordMatch) []string {
+	flags := make([]string, 0, len(matches))
+	for _, m := range matches {
+		flags = append(flags, m.Keyword)
+	}
+	return flags
+}
+
+// keywordRuleMatches explains keyword hits as rule matches
+func keywordRuleMatches(matches []keywordMatch) []entities.RuleMatch {
+	out := make([]entities.RuleMatch, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, entities.RuleMatch{
+			RuleID:      m.Rule.ID,
+			Description: m.Rule.Description,
+			Level:       m.Rule.Level,
+			Evidence:    m.Evidence,
+			Start:       m.Start,
+			End:         m.End,
+			Source:      entities.TriageSourceKeyword,
+		})
+	}
+	return out
+}
+
+// mergeRuleMatches appends the extra matches that do not repeat a rule and span already present
+func mergeRuleMatches(matches, extra []entities.RuleMatch) []entities.RuleMatch {
+	type key struct {
+		ruleID     string
+		start, end int
+	}
+	seen := make(map[key]bool, len(matches))
+	merged := make([]entities.RuleMatch, 0, len(matches)+len(extra))
+	for _, m := range append(append([]entities.RuleMatch{}, matches...), extra...) {
+		k := key{m.RuleID, m.Start, m.End}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// mergeFlags appends the extra flags that are not already present
+func mergeFlags(flags, extra []string) []string {
+	seen := make(map[string]bool, len(flags))
+	merged := make([]string, 0, len(flags)+len(extra))
+	for _, f := range append(append([]string{}, flags...), extra...) {
+		key := strings.ToLower(strings.TrimSpace(f))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, f)
+	}
+	return merged
+}
+
 // returns appropriate message based on triage level
 func (ts *TriageService) getTriageMessage(level entities.TriageLevel, language string) string {
 	switch level {
@@ -73,112 +222,161 @@ func (ts *TriageService) ValidateInput(inputText, lang string) error {
 	if strings.TrimSpace(inputText) == "" {
 		return fmt.Errorf("symptom text cannot be empty")
 	}
-	if len(inputText) < 3 {
+	// Count characters, not bytes: Ethiopic script takes three bytes per character
+	length := utf8.RuneCountInString(inputText)
+	if length < 3 {
 		return fmt.Errorf("symptom text too short (minimum 3 characters)")
 	}
-	if len(inputText) > 500 {
+	if length > 500 {
 		return fmt.Errorf("symptom text too long (maximum 500 characters)")
 	}
-	if lang != "en" && lang != "am" {
-		return fmt.Errorf("unsupported language: %s (supported: en, am)", lang)
+	if !languages.IsSupported(lang) {
+		return fmt.Errorf("unsupported language: %s (supported: %s)", lang, strings.Join(languages.Codes(), ", "))
 	}
 	return nil
 }
 
-// performs LLM-based triage classification using data-driven prompts
-func (ts *TriageService) classifyWithLLM(ctx context.Context, inputText, lang string) (entities.TriageLevel, []string, error) {
-	redFlagPrompt := ts.formatRedFlagRulesForPrompt(lang)
-	yellowFlagPrompt := ts.formatYellowFlagRulesForPrompt(lang)
-	approvedTopicsPrompt := ts.formatApprovedTopicsForPrompt(lang)
-
-	prompt := fmt.Sprintf(`
-You are a medical triage classifier. Analyze the user input and determine if it describes a medical emergency.
-Your ONLY output must be a single JSON object with this exact structure:
-{"level": "RED" | "YELLOW" | "GREEN" | "UNCLEAR", "flags": ["flag1", "flag2"]}
-
-CRITICAL RED FLAGS (output RED if you detect any of these):
-%s
+// triageResponseSchema is the shape the triage prompt asks the LLM to return
+var triageResponseSchema = llmoutput.Schema{
+	Required: []string{"level"},
+	Enum:     map[string][]string{"level": {"RED", "YELLOW", "GREEN", "UNCLEAR"}},
+}
 
-YELLOW FLAGS (output YELLOW if you detect any of these, but no red flags):
-%s
+// llmVerdict is the parsed LLM classification together with what is needed to audit it
+type llmVerdict struct {
+	Level         entities.TriageLevel
+	Flags         []string
+	Matches       []entities.RuleMatch
+	Unclear       bool
+	PromptHash    string
+	PromptVersion string
+	RawResponse   string
+	Provider      string
+	Latency       time.Duration
+
+	// Set when the verdict combines several samples (see classifyWithConsensus)
+	Calls     int
+	Agreement float64
+	Samples   []entities.TriageSample
+}
 
-GREEN FLAGS (output GREEN only if the symptom matches one of these approved topics):
-%s
+// triageMaxTokens leaves room for rule citations with evidence quotes
+const triageMaxTokens = 256
 
-UNCLEAR: If the input doesn't clearly match any of the above categories, or if you cannot understand what the user is describing.
+// performs LLM-based triage classification using data-driven prompts and the triage prompt template.
+// The returned verdict is never nil so the audit trace is kept even on failure.
+func (ts *TriageService) classifyWithLLM(ctx context.Context, inputText, lang string, patient *entities.PatientContext, rules entities.TriageRuleSnapshot, temperature *float32) (*llmVerdict, error) {
+	redFlagPrompt := formatRulesForPrompt(rules.RedRules, lang)
+	yellowFlagPrompt := formatRulesForPrompt(rules.YellowRules, lang)
+	approvedTopicsPrompt := ts.formatApprovedTopicsForPrompt(lang)
 
-Be conservative - when in doubt about red/yellow flags, escalate to YELLOW or RED.
-Only return GREEN if the symptom clearly matches one of the approved topics.
+	prompt, err := ts.prompts.Render(dto.LLMFeatureTriage, map[string]string{
+		"RedFlags":    redFlagPrompt,
+		"YellowFlags": yellowFlagPrompt,
+		"Topics":      approvedTopicsPrompt,
+		"Patient":     formatPatientForPrompt(patient),
+		"Language":    languages.Name(lang),
+		"Input":       inputText,
+	})
+	if err != nil {
+		return &llmVerdict{}, err
+	}
+	request := prompt.Request()
+	request.JSONMode = true
+	request.MaxTokens = triageMaxTokens
+	request.Temperature = temperature
+	request.Feature = dto.LLMFeatureTriage
+	// Consensus samples must stay independent votes, so only single-shot triage is cached.
+	// Cached replies are tied to the rules version and dropped when the rules change.
+	if ts.config.ConsensusSamples <= 1 {
+		request.CacheScope = dto.LLMFeatureTriage
+		request.CacheVersion = rules.Version
+	}
 
-User Input (Language: %s): "%s"
-	`,
-		redFlagPrompt,
-		yellowFlagPrompt,
-		approvedTopicsPrompt,
-		lang,
-		inputText)
+	promptSum := sha256.Sum256([]byte(request.System + "\n" + request.Messages[0].Content))
+	verdict := &llmVerdict{PromptHash: hex.EncodeToString(promptSum[:]), PromptVersion: prompt.Ref}
 
-	response, err := ts.llmClient.ClassifyTriage(ctx, prompt)
+	started := time.Now()
+	response, provider, err := ts.generate(ctx, request)
+	verdict.Latency = time.Since(started)
 	if err != nil {
-		return entities.TriageLevelGreen, nil, fmt.Errorf("LLM API call failed: %w", err)
+		return verdict, fmt.Errorf("LLM API call failed: %w", err)
 	}
-
-	// Clean the response by removing markdown formatting
-	cleanedResponse := strings.TrimSpace(response)
-	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```json")
-	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```")
-	cleanedResponse = strings.TrimSuffix(cleanedResponse, "```")
-	cleanedResponse = strings.TrimSpace(cleanedResponse)
+	verdict.RawResponse = response
+	verdict.Provider = provider
 
 	var llmResult struct {
-		Level string   `json:"level"`
-		Flags []string `json:"flags"`
+		Level   string   `json:"level"`
+		Flags   []string `json:"flags"`
+		Matches []struct {
+			RuleID   string `json:"rule_id"`
+			Evidence string `json:"evidence"`
+		} `json:"matches"`
 	}
-	if err := json.Unmarshal([]byte(cleanedResponse), &llmResult); err != nil {
-		return entities.TriageLevelGreen, nil, fmt.Errorf("failed to parse LLM response: %w", err)
+	if err := llmoutput.Decode(response, &llmResult, triageResponseSchema); err != nil {
+		return verdict, fmt.Errorf("failed to parse LLM response: %w", err)
 	}
 
-	var level entities.TriageLevel
 	switch llmResult.Level {
 	case "RED":
-		level = entities.TriageLevelRed
+		verdict.Level = entities.TriageLevelRed
 	case "YELLOW":
-		level = entities.TriageLevelYellow
+		verdict.Level = entities.TriageLevelYellow
 	case "GREEN":
-		level = entities.TriageLevelGreen
+		verdict.Level = entities.TriageLevelGreen
 	case "UNCLEAR":
-		// Return a special response asking for clarification
-		return entities.TriageLevelGreen, []string{"unclear_input"}, nil
+		// Caller asks the user for clarification
+		verdict.Unclear = true
+		return verdict, nil
 	default:
-		return entities.TriageLevelGreen, nil, fmt.Errorf("LLM returned invalid triage level: %s", llmResult.Level)
+		return verdict, fmt.Errorf("LLM returned invalid triage level: %s", llmResult.Level)
 	}
 
-	return level, llmResult.Flags, nil
-}
-
-// formats red flag rules for inclusion in LLM prompts
-func (ts *TriageService) formatRedFlagRulesForPrompt(language string) string {
-	redFlagRules := ts.getRedFlagRulesOnly()
-	var ruleDescriptions []string
+	verdict.Flags = llmResult.Flags
 
-	for _, rule := range redFlagRules {
-		if rule.Language == language {
-			desc := fmt.Sprintf("%s: %s", strings.Join(rule.Keywords, ", "), rule.Description)
-			ruleDescriptions = append(ruleDescriptions, desc)
+	// Only keep citations of rules that exist; a rule whose evidence really is in the
+	// input raises the level to the rule's level
+	rulesByID := rulesByID(rules, lang)
+	for _, cited := range llmResult.Matches {
+		rule, ok := rulesByID[cited.RuleID]
+		if !ok {
+			continue
 		}
+		match := entities.RuleMatch{
+			RuleID:      rule.ID,
+			Description: rule.Description,
+			Level:       rule.Level,
+			Start:       -1,
+			End:         -1,
+			Source:      entities.TriageSourceLLM,
+		}
+		if evidence, start, end, found := locateEvidence(inputText, cited.Evidence); found {
+			match.Evidence, match.Start, match.End = evidence, start, end
+			verdict.Level = entities.MoreSevere(verdict.Level, rule.Level)
+		}
+		verdict.Matches = mergeRuleMatches(verdict.Matches, []entities.RuleMatch{match})
 	}
+	return verdict, nil
+}
 
-	return strings.Join(ruleDescriptions, "\n")
+// rulesByID indexes the rules for a language by ID
+func rulesByID(rules entities.TriageRuleSnapshot, language string) map[string]entities.RedFlagRule {
+	byID := make(map[string]entities.RedFlagRule)
+	for _, rule := range append(append([]entities.RedFlagRule{}, rules.RedRules...), rules.YellowRules...) {
+		if rule.Language == language && rule.ID != "" {
+			byID[rule.ID] = rule
+		}
+	}
+	return byID
 }
 
-// formats yellow flag rules for inclusion in LLM prompts
-func (ts *TriageService) formatYellowFlagRulesForPrompt(language string) string {
-	yellowFlagRules := ts.getYellowFlagRulesOnly()
+// formats red or yellow flag rules for inclusion in LLM prompts
+func formatRulesForPrompt(rules []entities.RedFlagRule, language string) string {
 	var ruleDescriptions []string
 
-	for _, rule := range yellowFlagRules {
+	for _, rule := range rules {
 		if rule.Language == language {
-			desc := fmt.Sprintf("%s: %s", strings.Join(rule.Keywords, ", "), rule.Description)
+			desc := fmt.Sprintf("[%s] %s: %s", rule.ID, strings.Join(rule.Keywords, ", "), rule.Description)
 			ruleDescriptions = append(ruleDescriptions, desc)
 		}
 	}
@@ -186,6 +384,27 @@ func (ts *TriageService) formatYellowFlagRulesForPrompt(language string) string
 	return strings.Join(ruleDescriptions, "\n")
 }
 
+// formatPatientForPrompt describes the patient context, or returns "" when none was given
+func formatPatientForPrompt(patient *entities.PatientContext) string {
+	if patient.IsEmpty() {
+		return ""
+	}
+	var parts []string
+	if patient.AgeBand != "" {
+		parts = append(parts, "age group: "+strings.ReplaceAll(string(patient.AgeBand), "_", " "))
+	}
+	if patient.Pregnancy == entities.PregnancyStatusPregnant {
+		parts = append(parts, "pregnant")
+	}
+	if len(patient.ChronicConditions) > 0 {
+		parts = append(parts, "chronic conditions: "+strings.ReplaceAll(strings.Join(patient.ChronicConditions, ", "), "_", " "))
+	}
+	return fmt.Sprintf(`
+Patient context (consider it when choosing the level; the same symptom can be more serious for this patient):
+%s
+`, strings.Join(parts, "; "))
+}
+
 // formatApprovedTopicsForPrompt formats approved topics for inclusion in LLM prompts
 func (ts *TriageService) formatApprovedTopicsForPrompt(language string) string {
 	approvedBlocks, err := ts.contentService.GetApprovedBlocks()
@@ -213,47 +432,26 @@ func min(a, b int) int {
 	return b
 }
 
-// gets only red flag rules from content service
-func (ts *TriageService) getRedFlagRulesOnly() []entities.RedFlagRule {
-	if cs, ok := ts.contentService.(*content.ContentService); ok {
-		return cs.GetRedFlagRulesOnly()
+// returns the current rule set from the content service
+func (ts *TriageService) ruleSnapshot() entities.TriageRuleSnapshot {
+	if rp, ok := ts.contentService.(interfaces.TriageRuleProvider); ok {
+		return rp.RuleSnapshot()
 	}
-	return nil
+	return entities.TriageRuleSnapshot{}
 }
 
-// gets only yellow flag rules from content service
-func (ts *TriageService) getYellowFlagRulesOnly() []entities.RedFlagRule {
-	if cs, ok := ts.contentService.(*content.ContentService); ok {
-		return cs.GetYellowFlagRules()
-	}
-	return nil
-}
-
-// Message helpers
 func (ts *TriageService) getRedFlagMessage(language string) string {
-	if language == "am" {
-		return "ወዲያውኑ የህክምና እርዳታ ይፈልጉ። ወደ ቅርብ ሆስፒታል ወይም የድንገተኛ ጊዜ አገልግሎት ይሂዱ።"
-	}
-	return "Seek emergency care immediately. Go to the nearest hospital or emergency service."
+	return ts.messages.Message(language, "triage.red", nil)
 }
 
 func (ts *TriageService) getYellowFlagMessage(language string) string {
-	if language == "am" {
-		return "ምልክቶችዎን በጥንቃቄ ይከታተሉ። ካልተሻሻለ ወይም ከባሰ የህክምና ባለሙያ ያማክሩ።"
-	}
-	return "Monitor your symptoms closely. Consult a healthcare professional if they don't improve or worsen."
+	return ts.messages.Message(language, "triage.yellow", nil)
 }
 
 func (ts *TriageService) getGreenFlagMessage(language string) string {
-	if language == "am" {
-		return "ምልክቶችዎ ቀላል ሊሆኑ ይችላሉ። የራስ እንክብካቤ ምክሮችን ይከተሉ።"
-	}
-	return "Your symptoms appear to be mild. Follow self-care recommendations."
+	return ts.messages.Message(language, "triage.green", nil)
 }
 
 func (ts *TriageService) getClarificationMessage(language string) string {
-	if language == "am" {
-		return "ይቅርታ፣ የገለጹልኝን ምልክቶች በግልጽ ለመረዳት አልቻልኩም። ምልክቶችዎን በሌላ መንገድ ሊያስረዱኝ ወይም ተጨማሪ ዝርዝር ሊሰጡኝ ይችላሉ?"
-	}
-	return "I'm sorry, I couldn't clearly understand the symptoms you described. Could you please explain your symptoms in a different way or provide more details?"
+	return ts.messages.Message(language, "triage.clarification", nil)
 }
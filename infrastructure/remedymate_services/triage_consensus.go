@@ -0,0 +1,126 @@
+package remedymate_services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+)
+
+const unclearVote = "UNCLEAR"
+
+// classifyWithConsensus asks the LLM ConsensusSamples times and combines the votes.
+// The most severe vote wins, and any disagreement (including failed samples) raises
+// the result to at least YELLOW. With sampling disabled it is a single classifyWithLLM call.
+func (ts *TriageService) classifyWithConsensus(ctx context.Context, inputText, lang string, patient *entities.PatientContext, rules entities.TriageRuleSnapshot) (*llmVerdict, error) {
+	samples := ts.config.ConsensusSamples
+	if samples <= 1 {
+		verdict, err := ts.classifyWithLLM(ctx, inputText, lang, patient, rules, ts.sampleTemperature(0))
+		verdict.Calls = 1
+		if err == nil {
+			verdict.Agreement = 1
+		}
+		return verdict, err
+	}
+
+	verdicts := make([]*llmVerdict, samples)
+	errs := make([]error, samples)
+	var wg sync.WaitGroup
+	for i := 0; i < samples; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			verdicts[i], errs[i] = ts.classifyWithLLM(ctx, inputText, lang, patient, rules, ts.sampleTemperature(i))
+		}(i)
+	}
+	wg.Wait()
+
+	combined := &llmVerdict{
+		PromptHash:    verdicts[0].PromptHash,
+		PromptVersion: verdicts[0].PromptVersion,
+		Provider:      verdicts[0].Provider,
+		Calls:         samples,
+		Samples:       make([]entities.TriageSample, 0, samples),
+	}
+	votes := make([]string, 0, samples)
+	var firstErr error
+	for i, v := range verdicts {
+		// Samples run in parallel, so the decision took as long as the slowest one
+		if v.Latency > combined.Latency {
+			combined.Latency = v.Latency
+		}
+		sample := entities.TriageSample{
+			Temperature: ts.sampleTemperature(i),
+			RawResponse: v.RawResponse,
+			Provider:    v.Provider,
+			LatencyMs:   v.Latency.Milliseconds(),
+		}
+		if errs[i] != nil {
+			sample.Error = errs[i].Error()
+			combined.Samples = append(combined.Samples, sample)
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		if combined.RawResponse == "" {
+			combined.RawResponse = v.RawResponse
+			combined.Provider = v.Provider
+		}
+		if v.Unclear {
+			sample.Level = unclearVote
+		} else {
+			sample.Level = string(v.Level)
+			combined.Level = entities.MoreSevere(combined.Level, v.Level)
+			combined.Flags = mergeFlags(combined.Flags, v.Flags)
+			combined.Matches = mergeRuleMatches(combined.Matches, v.Matches)
+		}
+		votes = append(votes, sample.Level)
+		combined.Samples = append(combined.Samples, sample)
+	}
+
+	if len(votes) == 0 {
+		return combined, fmt.Errorf("all %d triage samples failed: %w", samples, firstErr)
+	}
+
+	final := string(combined.Level)
+	if combined.Level == "" {
+		combined.Unclear = true
+		final = unclearVote
+	}
+	agreeing := 0
+	for _, vote := range votes {
+		if vote == final {
+			agreeing++
+		}
+	}
+	combined.Agreement = float64(agreeing) / float64(samples)
+
+	if agreeing < samples {
+		combined.Unclear = false
+		combined.Level = entities.MoreSevere(combined.Level, entities.TriageLevelYellow)
+	}
+
+	return combined, nil
+}
+
+// sampleTemperature returns the configured temperature for the i-th sample, or nil for the client default
+func (ts *TriageService) sampleTemperature(i int) *float32 {
+	temps := ts.config.ConsensusTemperatures
+	if len(temps) == 0 {
+		return nil
+	}
+	t := temps[i%len(temps)]
+	return &t
+}
+
+// generate sends the request and returns the response text and the provider that served it
+func (ts *TriageService) generate(ctx context.Context, request dto.GenerateRequest) (string, string, error) {
+	resp, err := ts.llmClient.Generate(ctx, request)
+	if err != nil {
+		return "", "", err
+	}
+	return resp.Text, resp.Provider, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LLMCacheRepositoryImpl struct {
+	coll       *mongo.Collection
+	maxEntries int
+}
+
+// NewLLMCacheRepository stores cached LLM replies in Mongo so they survive restarts and
+// are shared between instances. A TTL index removes expired entries; maxEntries 0 is unlimited.
+func NewLLMCacheRepository(maxEntries int) interfaces.LLMCacheStore {
+	c := database.Client.Database("remedymate").Collection("llm_cache")
+	_, _ = c.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		{Keys: bson.D{{Key: "scope", Value: 1}, {Key: "version", Value: 1}}},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}}},
+	})
+	return &LLMCacheRepositoryImpl{coll: c, maxEntries: maxEntries}
+}
+
+func (r *LLMCacheRepositoryImpl) Get(ctx context.Context, key string) (*entities.LLMCacheEntry, bool, error) {
+	var entry entities.LLMCacheEntry
+	err := r.coll.FindOne(ctx, bson.M{"_id": key}).Decode(&entry)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	// The TTL monitor only runs about once a minute
+	if entry.Expired(time.Now()) {
+		return nil, false, nil
+	}
+	return &entry, true, nil
+}
+
+func (r *LLMCacheRepositoryImpl) Set(ctx context.Context, entry *entities.LLMCacheEntry) error {
+	_, err := r.coll.ReplaceOne(ctx, bson.M{"_id": entry.Key}, entry, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	return r.trim(ctx)
+}
+
+func (r *LLMCacheRepositoryImpl) DeleteScope(ctx context.Context, scope, keepVersion string) (int64, error) {
+	res, err := r.coll.DeleteMany(ctx, bson.M{"scope": scope, "version": bson.M{"$ne": keepVersion}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (r *LLMCacheRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.coll.EstimatedDocumentCount(ctx)
+}
+
+// trim removes the oldest entries once the collection grows past maxEntries
+func (r *LLMCacheRepositoryImpl) trim(ctx context.Context) error {
+	if r.maxEntries <= 0 {
+		return nil
+	}
+	total, err := r.coll.EstimatedDocumentCount(ctx)
+	if err != nil || total <= int64(r.maxEntries) {
+		return err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetLimit(total - int64(r.maxEntries)).
+		SetProjection(bson.M{"_id": 1})
+	cur, err := r.coll.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	var keys []string
+	for cur.Next(ctx) {
+		var doc struct {
+			Key string `bson:"_id"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return err
+		}
+		keys = append(keys, doc.Key)
+	}
+	if err := cur.Err(); err != nil || len(keys) == 0 {
+		return err
+	}
+	_, err = r.coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": keys}})
+	return err
+}
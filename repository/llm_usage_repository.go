@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LLMUsageRepositoryImpl struct {
+	coll *mongo.Collection
+}
+
+func NewLLMUsageRepository() interfaces.LLMUsageRepository {
+	c := database.Client.Database("remedymate").Collection("llm_usage")
+	_, _ = c.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "feature", Value: 1}, {Key: "createdAt", Value: -1}}},
+	})
+	return &LLMUsageRepositoryImpl{coll: c}
+}
+
+func (r *LLMUsageRepositoryImpl) Create(ctx context.Context, record *entities.LLMUsageRecord) error {
+	record.ID = primitive.NewObjectID().Hex()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	_, err := r.coll.InsertOne(ctx, record)
+	return err
+}
+
+// DailyAggregates sums calls and tokens per UTC day, feature, prompt version and model, newest day first
+func (r *LLMUsageRepositoryImpl) DailyAggregates(ctx context.Context, filter dto.LLMUsageFilter) ([]dto.LLMUsageDaily, error) {
+	query := bson.M{}
+	if filter.Feature != "" {
+		query["feature"] = filter.Feature
+	}
+	if filter.From != nil || filter.To != nil {
+		createdAt := bson.M{}
+		if filter.From != nil {
+			createdAt["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			createdAt["$lt"] = *filter.To
+		}
+		query["createdAt"] = createdAt
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"date":          bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$createdAt"}},
+				"feature":       "$feature",
+				"promptVersion": "$promptVersion",
+				"model":         "$model",
+			},
+			"calls":            bson.M{"$sum": 1},
+			"failures":         bson.M{"$sum": bson.M{"$cond": bson.A{"$success", 0, 1}}},
+			"promptTokens":     bson.M{"$sum": "$promptTokens"},
+			"completionTokens": bson.M{"$sum": "$completionTokens"},
+			"totalTokens":      bson.M{"$sum": "$totalTokens"},
+			"avgLatencyMs":     bson.M{"$avg": "$latencyMs"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":              0,
+			"date":             "$_id.date",
+			"feature":          "$_id.feature",
+			"promptVersion":    "$_id.promptVersion",
+			"model":            "$_id.model",
+			"calls":            1,
+			"failures":         1,
+			"promptTokens":     1,
+			"completionTokens": 1,
+			"totalTokens":      1,
+			"avgLatencyMs":     1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "date", Value: -1}, {Key: "feature", Value: 1}, {Key: "promptVersion", Value: 1}, {Key: "model", Value: 1}}}},
+	}
+	cur, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]dto.LLMUsageDaily, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TotalTokensSince sums the tokens of all calls made since the given time
+func (r *LLMUsageRepositoryImpl) TotalTokensSince(ctx context.Context, since time.Time) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"createdAt": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$totalTokens"}}}},
+	}
+	cur, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	var result []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cur.All(ctx, &result); err != nil || len(result) == 0 {
+		return 0, err
+	}
+	return result[0].Total, nil
+}
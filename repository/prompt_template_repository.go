@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	AppError "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PromptTemplateRepositoryImpl struct {
+	coll *mongo.Collection
+}
+
+func NewPromptTemplateRepository() interfaces.PromptTemplateRepository {
+	c := database.Client.Database("remedymate").Collection("prompt_templates")
+	_, _ = c.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "version", Value: -1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "active", Value: 1}}},
+	})
+	return &PromptTemplateRepositoryImpl{coll: c}
+}
+
+func (r *PromptTemplateRepositoryImpl) List(ctx context.Context, name string) ([]entities.PromptTemplate, error) {
+	filter := bson.M{}
+	if name != "" {
+		filter["name"] = name
+	}
+	cur, err := r.coll.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "version", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]entities.PromptTemplate, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *PromptTemplateRepositoryImpl) Get(ctx context.Context, name string, version int) (*entities.PromptTemplate, error) {
+	var t entities.PromptTemplate
+	err := r.coll.FindOne(ctx, bson.M{"name": name, "version": version}).Decode(&t)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, AppError.ErrPromptTemplateNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *PromptTemplateRepositoryImpl) Create(ctx context.Context, t *entities.PromptTemplate) error {
+	t.ID = entities.PromptVersionRef(t.Name, t.Version)
+	t.Active = false
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	_, err := r.coll.InsertOne(ctx, t)
+	return err
+}
+
+func (r *PromptTemplateRepositoryImpl) Activate(ctx context.Context, name string, version int, actor string) error {
+	now := time.Now()
+	res, err := r.coll.UpdateOne(ctx,
+		bson.M{"name": name, "version": version},
+		bson.M{"$set": bson.M{"active": true, "activatedAt": now, "activatedBy": actor}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return AppError.ErrPromptTemplateNotFound
+	}
+	_, err = r.coll.UpdateMany(ctx,
+		bson.M{"name": name, "version": bson.M{"$ne": version}, "active": true},
+		bson.M{"$set": bson.M{"active": false}},
+	)
+	return err
+}
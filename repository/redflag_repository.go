@@ -24,6 +24,7 @@ func NewRedFlagRepository() interfaces.RedFlagRepository {
 		{Keys: bson.D{{Key: "language", Value: 1}, {Key: "level", Value: 1}}},
 		{Keys: bson.D{{Key: "isDeleted", Value: 1}}},
 		{Keys: bson.D{{Key: "description", Value: "text"}}},
+		{Keys: bson.D{{Key: "seedId", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
 	})
 	return &RedFlagRepositoryImpl{coll: c}
 }
@@ -77,3 +78,35 @@ func (r *RedFlagRepositoryImpl) SoftDelete(ctx context.Context, id string, delet
 	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"isDeleted": true, "deletedAt": now, "deletedBy": deletedBy}})
 	return err
 }
+
+// SeedRule inserts a bundled rule unless a rule with its seed ID, the "id" in the rule file, is
+// already stored, deleted or not, so editing a bundled rule never adds it twice. Rules seeded
+// before seed IDs were recorded are matched on level, language and description and get the seed
+// ID instead. Reports whether the rule was inserted.
+func (r *RedFlagRepositoryImpl) SeedRule(ctx context.Context, rf *entities.RedFlag) (bool, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"seedId": rf.SeedID},
+		{"seedId": bson.M{"$exists": false}, "level": rf.Level, "language": rf.Language, "description": rf.Description},
+	}}
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"seedId": rf.SeedID},
+		"$setOnInsert": bson.M{
+			"_id":         primitive.NewObjectID().Hex(),
+			"keywords":    rf.Keywords,
+			"language":    rf.Language,
+			"level":       rf.Level,
+			"description": rf.Description,
+			"conditions":  rf.Conditions,
+			"isDeleted":   false,
+			"createdAt":   now,
+			"updatedAt":   now,
+			"createdBy":   rf.CreatedBy,
+		},
+	}
+	result, err := r.coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
@@ -83,6 +83,20 @@ func (tr *TopicRepository) GetTopicByKey(ctx context.Context, key string) (*enti
 	return &topic, nil
 }
 
+// GetTopicIncludingDeleted returns the topic for the given key whatever its status, for looking
+// back at the history of a topic that was deleted.
+func (tr *TopicRepository) GetTopicIncludingDeleted(ctx context.Context, key string) (*entities.Topic, error) {
+	var topic entities.Topic
+	err := tr.TopicCollection.FindOne(ctx, bson.M{"topic_key": key}).Decode(&topic)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AppError.ErrTopicNotFound
+		}
+		return nil, fmt.Errorf("failed to query topic by key: %w", err)
+	}
+	return &topic, nil
+}
+
 // UpdateTopic applies a partial update to the topic identified by topicKey.
 func (tr *TopicRepository) UpdateTopic(ctx context.Context, topicKey string, update *entities.Topic) error {
 	updateFields := bson.M{
@@ -95,10 +109,19 @@ func (tr *TopicRepository) UpdateTopic(ctx context.Context, topicKey string, upd
 	updateFields["description_en"] = update.DescriptionEN
 	updateFields["description_am"] = update.DescriptionAM
 	updateFields["status"] = update.Status
+	updateFields["state"] = update.State
+	updateFields["draft"] = update.Draft
+	updateFields["transitions"] = update.Transitions
+	updateFields["published_at"] = update.PublishedAt
+	updateFields["published_by"] = update.PublishedBy
+	updateFields["revision_history"] = update.RevisionHistory
 
 	if update.Translations != nil {
 		updateFields["translations"] = update.Translations
 	}
+	if update.Synonyms != nil {
+		updateFields["synonyms"] = update.Synonyms
+	}
 	// increment version if provided/expected
 	if update.Version > 0 {
 		updateFields["version"] = update.Version
@@ -123,11 +146,15 @@ func (tr *TopicRepository) DeleteTopic(ctx context.Context, topicKey string, del
 		"topic_key": topicKey,
 		"status":    bson.M{"$ne": entities.TopicStatusDeleted},
 	}
+	deletedBy, err := primitive.ObjectIDFromHex(deletedByUserID)
+	if err != nil {
+		return AppError.ErrInvalidInput
+	}
 	update := bson.M{
 		"$set": bson.M{
 			"status":     entities.TopicStatusDeleted,
 			"updated_at": time.Now(),
-			"updated_by": deletedByUserID,
+			"updated_by": deletedBy,
 		},
 	}
 	result, err := tr.TopicCollection.UpdateOne(ctx, filter, update)
@@ -148,6 +175,9 @@ func (tr *TopicRepository) ListAllTopics(ctx context.Context, params dto.TopicLi
 	filter := bson.M{
 		"status": bson.M{"$ne": entities.TopicStatusDeleted},
 	}
+	if params.State != "" {
+		filter["state"] = params.State
+	}
 	if params.Search != "" {
 		regex := primitive.Regex{Pattern: params.Search, Options: "i"}
 		filter["$or"] = []bson.M{
@@ -208,6 +238,31 @@ func (tr *TopicRepository) ListAllTopics(ctx context.Context, params dto.TopicLi
 	return topics, total, nil
 }
 
+// ListPublishedTopics returns every topic users may be served, ordered by topic_key: not
+// soft-deleted or archived, and published at least once. Topics saved before the editorial
+// workflow have no state and count as published.
+func (tr *TopicRepository) ListPublishedTopics(ctx context.Context) ([]*entities.Topic, error) {
+	filter := bson.M{
+		"status": bson.M{"$ne": entities.TopicStatusDeleted},
+		"state":  bson.M{"$ne": entities.TopicStateArchived},
+		"$or": []bson.M{
+			{"published_at": bson.M{"$ne": nil}},
+			{"state": bson.M{"$exists": false}},
+		},
+	}
+	cursor, err := tr.TopicCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "topic_key", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published topics: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var topics []*entities.Topic
+	if err := cursor.All(ctx, &topics); err != nil {
+		return nil, fmt.Errorf("failed to decode topics: %w", err)
+	}
+	return topics, nil
+}
+
 func (tr *TopicRepository) CheckTopicExists(ctx context.Context, topicKey string) (bool, error) {
 	var count int64
 	filter := bson.M{"topic_key": topicKey}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TopicRevisionRepository stores published topic snapshots. Revisions are insert-only.
+type TopicRevisionRepository struct {
+	coll *mongo.Collection
+}
+
+// NewTopicRevisionRepository creates the repository with a unique (topic_key, version) index.
+func NewTopicRevisionRepository() interfaces.TopicRevisionRepository {
+	c := database.Client.Database("remedymate").Collection("topic_revisions")
+	_, _ = c.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "topic_key", Value: 1}, {Key: "version", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "topic_key", Value: 1}, {Key: "published_at", Value: -1}}},
+	})
+	return &TopicRevisionRepository{coll: c}
+}
+
+// Create inserts a revision; a second revision for the same version is rejected.
+func (r *TopicRevisionRepository) Create(ctx context.Context, revision *entities.TopicRevision) error {
+	if revision.PublishedAt.IsZero() {
+		revision.PublishedAt = time.Now()
+	}
+	if _, err := r.coll.InsertOne(ctx, revision); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return AppError.ErrTopicRevisionExists
+		}
+		return fmt.Errorf("failed to insert topic revision: %w", err)
+	}
+	return nil
+}
+
+// List returns every revision of a topic, oldest first.
+func (r *TopicRevisionRepository) List(ctx context.Context, topicKey string) ([]entities.TopicRevision, error) {
+	cur, err := r.coll.Find(ctx, bson.M{"topic_key": topicKey}, options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topic revisions: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	out := make([]entities.TopicRevision, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode topic revisions: %w", err)
+	}
+	return out, nil
+}
+
+// Get returns one version of a topic.
+func (r *TopicRevisionRepository) Get(ctx context.Context, topicKey string, version int) (*entities.TopicRevision, error) {
+	var revision entities.TopicRevision
+	err := r.coll.FindOne(ctx, bson.M{"topic_key": topicKey, "version": version}).Decode(&revision)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, AppError.ErrTopicRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to query topic revision: %w", err)
+	}
+	return &revision, nil
+}
+
+// LatestAt returns the last revision published at or before the given time.
+func (r *TopicRevisionRepository) LatestAt(ctx context.Context, topicKey string, at time.Time) (*entities.TopicRevision, error) {
+	var revision entities.TopicRevision
+	filter := bson.M{"topic_key": topicKey, "published_at": bson.M{"$lte": at}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "version", Value: -1}})
+	if err := r.coll.FindOne(ctx, filter, opts).Decode(&revision); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, AppError.ErrTopicRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to query topic revision: %w", err)
+	}
+	return &revision, nil
+}
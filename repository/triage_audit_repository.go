@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TriageAuditRepositoryImpl struct {
+	coll      *mongo.Collection
+	snapshots *mongo.Collection
+}
+
+func NewTriageAuditRepository() interfaces.TriageAuditRepository {
+	db := database.Client.Database("remedymate")
+	c := db.Collection("triage_audits")
+	_, _ = c.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "level", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "language", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "sessionId", Value: 1}}},
+		{Keys: bson.D{{Key: "matchedRules.rule_id", Value: 1}}},
+	})
+	return &TriageAuditRepositoryImpl{
+		coll:      c,
+		snapshots: db.Collection("triage_rule_snapshots"),
+	}
+}
+
+func (r *TriageAuditRepositoryImpl) Create(ctx context.Context, audit *entities.TriageAudit) error {
+	audit.ID = primitive.NewObjectID().Hex()
+	if audit.CreatedAt.IsZero() {
+		audit.CreatedAt = time.Now()
+	}
+	_, err := r.coll.InsertOne(ctx, audit)
+	return err
+}
+
+func (r *TriageAuditRepositoryImpl) GetByID(ctx context.Context, id string) (*entities.TriageAudit, error) {
+	var audit entities.TriageAudit
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&audit)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, AppError.ErrTriageAuditNotFound
+		}
+		return nil, err
+	}
+	return &audit, nil
+}
+
+func (r *TriageAuditRepositoryImpl) Search(ctx context.Context, filter dto.TriageAuditFilter) ([]entities.TriageAudit, int64, error) {
+	query := auditQuery(filter)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(int64(filter.Limit)).
+		SetSkip(int64(filter.Offset))
+	cur, err := r.coll.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	// Initialize empty slice to ensure JSON marshals as [] instead of null
+	out := make([]entities.TriageAudit, 0)
+
+	for cur.Next(ctx) {
+		var audit entities.TriageAudit
+		if err := cur.Decode(&audit); err != nil {
+			return nil, 0, err
+		}
+		out = append(out, audit)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, total, nil
+}
+
+func (r *TriageAuditRepositoryImpl) RuleStats(ctx context.Context, filter dto.TriageAuditFilter) ([]dto.TriageRuleStat, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: auditQuery(filter)}},
+		{{Key: "$unwind", Value: "$matchedRules"}},
+		{{Key: "$match", Value: bson.M{"matchedRules.rule_id": bson.M{"$ne": ""}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$matchedRules.rule_id",
+			"description":   bson.M{"$last": "$matchedRules.description"},
+			"level":         bson.M{"$last": "$matchedRules.level"},
+			"count":         bson.M{"$sum": 1},
+			"keywordCount":  bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$matchedRules.source", string(entities.TriageSourceKeyword)}}, 1, 0}}},
+			"llmCount":      bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$matchedRules.source", string(entities.TriageSourceLLM)}}, 1, 0}}},
+			"lastMatchedAt": bson.M{"$max": "$createdAt"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	cur, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	out := make([]dto.TriageRuleStat, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// auditQuery builds the Mongo filter shared by audit search and rule stats
+func auditQuery(filter dto.TriageAuditFilter) bson.M {
+	query := bson.M{}
+	if filter.Level != "" {
+		query["level"] = strings.ToUpper(filter.Level)
+	}
+	if filter.Language != "" {
+		query["language"] = filter.Language
+	}
+	if filter.From != nil || filter.To != nil {
+		createdAt := bson.M{}
+		if filter.From != nil {
+			createdAt["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			createdAt["$lt"] = *filter.To
+		}
+		query["createdAt"] = createdAt
+	}
+	return query
+}
+
+func (r *TriageAuditRepositoryImpl) SaveRuleSnapshot(ctx context.Context, snapshot *entities.TriageRuleSnapshot) error {
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = time.Now()
+	}
+	// Snapshots are immutable: only the first write for a version is kept
+	_, err := r.snapshots.UpdateOne(ctx,
+		bson.M{"_id": snapshot.Version},
+		bson.M{"$setOnInsert": snapshot},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *TriageAuditRepositoryImpl) GetRuleSnapshot(ctx context.Context, version string) (*entities.TriageRuleSnapshot, error) {
+	var snapshot entities.TriageRuleSnapshot
+	err := r.snapshots.FindOne(ctx, bson.M{"_id": version}).Decode(&snapshot)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, AppError.ErrRuleSnapshotNotFound
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
@@ -17,9 +17,18 @@ type MockLLMClient struct {
 	mock.Mock
 }
 
-func (m *MockLLMClient) ClassifyTriage(ctx context.Context, prompt string) (string, error) {
-	args := m.Called(ctx, prompt)
-	return args.String(0), args.Error(1)
+// Generate records the call with the system prompt and messages joined into one
+// prompt string, so expectations can match on the prompt text
+func (m *MockLLMClient) Generate(ctx context.Context, req dto.GenerateRequest) (*dto.GenerateResponse, error) {
+	parts := []string{req.System}
+	for _, msg := range req.Messages {
+		parts = append(parts, msg.Content)
+	}
+	args := m.Called(ctx, strings.Join(parts, "\n"))
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	return &dto.GenerateResponse{Text: args.String(0)}, nil
 }
 
 // TestConversationFlow tests the complete conversation flow
@@ -28,7 +37,7 @@ func TestConversationFlow(t *testing.T) {
 	mockLLM := &MockLLMClient{}
 
 	// Mock responses for question generation - match by content instead of length
-	mockLLM.On("ClassifyTriage", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+	mockLLM.On("Generate", mock.Anything, mock.MatchedBy(func(prompt string) bool {
 		return strings.Contains(prompt, "Generate exactly 5 targeted follow-up questions")
 	})).Return(`[
 		{"id": 1, "text": "How long have you had this headache?", "type": "duration", "required": true},
@@ -39,12 +48,12 @@ func TestConversationFlow(t *testing.T) {
 	]`, nil)
 
 	// Mock responses for answer validation
-	mockLLM.On("ClassifyTriage", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+	mockLLM.On("Generate", mock.Anything, mock.MatchedBy(func(prompt string) bool {
 		return strings.Contains(prompt, "Validate this answer to a medical question")
 	})).Return(`{"valid": true, "feedback": ""}`, nil)
 
 	// Mock response for health report generation
-	mockLLM.On("ClassifyTriage", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+	mockLLM.On("Generate", mock.Anything, mock.MatchedBy(func(prompt string) bool {
 		return strings.Contains(prompt, "Create a structured health report")
 	})).Return(`{
 		"symptom": "Headache",
@@ -60,7 +69,7 @@ func TestConversationFlow(t *testing.T) {
 	}`, nil)
 
 	// Create conversation service
-	conversationService := conversation.NewConversationService(mockLLM)
+	conversationService := conversation.NewConversationService(mockLLM, loadMessages(t), loadPrompts(t))
 
 	// Test question generation
 	questions, err := conversationService.GenerateQuestions(context.Background(), "headache", "en")
@@ -109,6 +118,22 @@ func TestConversationFlow(t *testing.T) {
 	mockLLM.AssertExpectations(t)
 }
 
+// TestSymptomFeedbackLocalized verifies that fallback feedback comes from the message catalog
+func TestSymptomFeedbackLocalized(t *testing.T) {
+	mockLLM := &MockLLMClient{}
+	mockLLM.On("Generate", mock.Anything, mock.Anything).Return(`{"valid": true, "urgency_level": "EMERGENCY"}`, nil)
+	messages := loadMessages(t)
+	conversationService := conversation.NewConversationService(mockLLM, messages, loadPrompts(t))
+
+	valid, feedback, err := conversationService.ValidateSymptom(context.Background(), "የደረት ህመም", "am")
+	if err != nil || !valid {
+		t.Fatalf("ValidateSymptom = %v, %q, %v; want valid", valid, feedback, err)
+	}
+	if want := messages.Message("am", "conversation.symptom.emergency", nil); feedback != want {
+		t.Errorf("feedback = %q, want %q", feedback, want)
+	}
+}
+
 // TestConversationDTOs tests the DTO structures
 func TestConversationDTOs(t *testing.T) {
 	// Test StartConversationRequest (no authentication required)
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"remedymate-backend/infrastructure/i18n"
+)
+
+// loadMessages loads the shipped message bundles
+func loadMessages(t *testing.T) *i18n.Catalog {
+	t.Helper()
+	messages, err := i18n.NewCatalog("../data/locales")
+	if err != nil {
+		t.Fatalf("failed to load message catalog: %v", err)
+	}
+	return messages
+}
+
+// TestMessageCatalog verifies fallback, parameters and plural forms
+func TestMessageCatalog(t *testing.T) {
+	dir := t.TempDir()
+	bundles := map[string]string{
+		"en.json": `{
+			"greeting": "Hello {name}",
+			"questions": {"one": "{count} question", "other": "{count} questions"},
+			"only.english": "English only"
+		}`,
+		"am.json": `{
+			"greeting": "ሰላም {name}",
+			"questions": {"one": "{count} ጥያቄ", "other": "{count} ጥያቄዎች"}
+		}`,
+	}
+	for name, body := range bundles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	messages, err := i18n.NewCatalog(dir)
+	if err != nil {
+		t.Fatalf("NewCatalog returned error: %v", err)
+	}
+
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{messages.Message("am", "greeting", map[string]interface{}{"name": "አበበ"}), "ሰላም አበበ"},
+		{messages.Message("am", "only.english", nil), "English only"},
+		{messages.Message("en", "missing.key", nil), "missing.key"},
+		{messages.Plural("en", "questions", 1, nil), "1 question"},
+		{messages.Plural("en", "questions", 0, nil), "0 questions"},
+		{messages.Plural("am", "questions", 0, nil), "0 ጥያቄ"},
+		{messages.Plural("am", "questions", 5, nil), "5 ጥያቄዎች"},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+
+	// A broken edit keeps the previous bundles in use
+	if err := os.WriteFile(filepath.Join(dir, "am.json"), []byte(`{"greeting": `), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := messages.Reload(); err == nil {
+		t.Error("Reload accepted an invalid bundle")
+	}
+	if got := messages.Message("am", "greeting", map[string]interface{}{"name": "አበበ"}); got != "ሰላም አበበ" {
+		t.Errorf("after failed reload got %q", got)
+	}
+}
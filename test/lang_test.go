@@ -0,0 +1,111 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"remedymate-backend/delivery/routers"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/util/lang"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// useLanguages makes the registry from config/languages.json the process-wide one for the test
+func useLanguages(t *testing.T) *lang.Registry {
+	t.Helper()
+	registry, err := lang.LoadRegistry("../config/languages.json")
+	if err != nil {
+		t.Fatalf("LoadRegistry returned error: %v", err)
+	}
+	previous := lang.Default()
+	lang.SetDefault(registry)
+	t.Cleanup(func() { lang.SetDefault(previous) })
+	return registry
+}
+
+// TestLanguageRegistry verifies that only enabled languages are accepted, that text falls back
+// to the default language, and that a broken configuration fails to load
+func TestLanguageRegistry(t *testing.T) {
+	registry := useLanguages(t)
+
+	// om and ti are configured but not enabled yet
+	if codes := strings.Join(registry.Codes(), ","); codes != "en,am" {
+		t.Errorf("Codes = %s, want en,am", codes)
+	}
+	for code, want := range map[string]bool{"en": true, "am": true, "om": false, "ti": false, "fr": false, "": false} {
+		if got := lang.IsSupported(code); got != want {
+			t.Errorf("IsSupported(%q) = %v, want %v", code, got, want)
+		}
+	}
+	if _, ok := registry.Get("om"); ok {
+		t.Error("Get returned the disabled language om")
+	}
+	if name := registry.Name("ti"); name != "Tigrinya" {
+		t.Errorf("Name(ti) = %q, want the configured name", name)
+	}
+
+	// The binding tag used by request DTOs follows the registry
+	routers.RegisterValidators()
+	rule := func(language string) error {
+		return binding.Validator.ValidateStruct(dto.CreateRedFlagDTO{Keywords: []string{"chest pain"}, Language: language, Level: "RED", Description: "Chest pain"})
+	}
+	for _, code := range []string{"en", "am"} {
+		if err := rule(code); err != nil {
+			t.Errorf("language %q rejected: %v", code, err)
+		}
+	}
+	for _, code := range []string{"om", "ti", "xx"} {
+		if err := rule(code); err == nil {
+			t.Errorf("language %q accepted", code)
+		}
+	}
+
+	// Missing text falls back along the chain to the default language
+	if chain := strings.Join(registry.Chain("am"), ","); chain != "am,en" {
+		t.Errorf("Chain(am) = %s, want am,en", chain)
+	}
+	if chain := strings.Join(registry.Chain("en"), ","); chain != "en" {
+		t.Errorf("Chain(en) = %s, want en", chain)
+	}
+	if got := registry.Pick("am", map[string]string{"en": "Rest", "am": "ያርፉ"}); got != "ያርፉ" {
+		t.Errorf("Pick(am) = %q, want the Amharic text", got)
+	}
+	if got := registry.Pick("am", map[string]string{"en": "Rest", "am": ""}); got != "Rest" {
+		t.Errorf("Pick(am) without Amharic = %q, want the English text", got)
+	}
+	chained, err := lang.NewRegistry("en", []lang.Language{
+		{Code: "en", Enabled: true},
+		{Code: "ti", Fallback: "am", Enabled: true},
+		{Code: "am", Fallback: "ti", Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry returned error: %v", err)
+	}
+	if chain := strings.Join(chained.Chain("ti"), ","); chain != "ti,am,en" {
+		t.Errorf("Chain(ti) with a fallback cycle = %s, want ti,am,en", chain)
+	}
+
+	broken := map[string]string{
+		"invalid JSON":      `{"default": "en", "languages": [`,
+		"disabled default":  `{"default": "om", "languages": [{"code": "en", "enabled": true}, {"code": "om", "enabled": false}]}`,
+		"unknown default":   `{"default": "fr", "languages": [{"code": "en", "enabled": true}]}`,
+		"duplicate code":    `{"default": "en", "languages": [{"code": "en", "enabled": true}, {"code": "EN", "enabled": true}]}`,
+		"missing code":      `{"default": "en", "languages": [{"code": "en", "enabled": true}, {"name": "Somali", "enabled": true}]}`,
+		"unknown fallback":  `{"default": "en", "languages": [{"code": "en", "enabled": true}, {"code": "am", "fallback": "xx", "enabled": true}]}`,
+		"no languages file": "",
+	}
+	for name, config := range broken {
+		path := filepath.Join(t.TempDir(), "languages.json")
+		if config != "" {
+			if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := lang.LoadRegistry(path); err == nil {
+			t.Errorf("%s: LoadRegistry succeeded", name)
+		}
+	}
+}
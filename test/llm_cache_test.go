@@ -0,0 +1,119 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/llm"
+	"remedymate-backend/infrastructure/remedymate_services"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// TestLLMResponseCache verifies the cache key, opt-in scopes, version invalidation and limits
+func TestLLMResponseCache(t *testing.T) {
+	ctx := context.Background()
+	config := dto.LLMCacheConfig{Backend: llm.CacheBackendMemory, TTL: time.Hour}
+
+	inner := &MockLLMClient{}
+	inner.On("Generate", mock.Anything, mock.Anything).Return(`{"topic_key": "headache"}`, nil)
+	store := llm.NewMemoryCacheStore(0)
+	client := llm.NewCachingClient(inner, store, config, "test/model")
+
+	request := func(prompt, version string) dto.GenerateRequest {
+		req := dto.PromptRequest("Map the symptom.", prompt)
+		req.CacheScope = "map_topic"
+		req.CacheVersion = version
+		return req
+	}
+
+	// Spacing differences share an entry
+	if _, err := client.Generate(ctx, request("I have a headache", "v1")); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	resp, err := client.Generate(ctx, request("  I have a\n headache ", "v1"))
+	if err != nil || !resp.Cached || resp.Text != `{"topic_key": "headache"}` {
+		t.Fatalf("second Generate = %+v, %v; want a cached reply", resp, err)
+	}
+	inner.AssertNumberOfCalls(t, "Generate", 1)
+
+	// Punctuation and case can change the meaning, so they do not
+	for _, prompt := range []string{"I have a headache!", "I have a Headache"} {
+		if resp, _ := client.Generate(ctx, request(prompt, "v1")); resp.Cached {
+			t.Errorf("%q was served the reply cached for \"I have a headache\"", prompt)
+		}
+	}
+	inner.AssertNumberOfCalls(t, "Generate", 3)
+
+	// Requests without a scope always reach the provider
+	client.Generate(ctx, dto.PromptRequest("Write a report.", "I have a headache"))
+	client.Generate(ctx, dto.PromptRequest("Write a report.", "I have a headache"))
+	inner.AssertNumberOfCalls(t, "Generate", 5)
+
+	// Another instance sharing the store at an older version keeps its entries apart
+	// and does not drop this one's
+	other := llm.NewCachingClient(inner, store, config, "test/model")
+	if resp, _ := other.Generate(ctx, request("I have a headache", "v0")); resp.Cached {
+		t.Error("reply cached under v1 was served for v0")
+	}
+	if n, _ := store.Count(ctx); n != 4 {
+		t.Errorf("cache holds %d entries with two versions in use, want 4", n)
+	}
+
+	// A new version misses and drops the entries cached under the old one
+	if resp, _ := client.Generate(ctx, request("I have a headache", "v2")); resp.Cached {
+		t.Error("reply cached under v1 was served for v2")
+	}
+	if n, _ := store.Count(ctx); n != 1 {
+		t.Errorf("cache holds %d entries after the version change, want 1", n)
+	}
+
+	stats, err := client.CacheStats(ctx)
+	if err != nil {
+		t.Fatalf("CacheStats returned error: %v", err)
+	}
+	if stats.Hits != 1 || stats.Misses != 4 || stats.Scopes["map_topic"].Hits != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	// Entries expire after the TTL and the oldest are evicted past MaxEntries
+	small := llm.NewMemoryCacheStore(2)
+	for _, key := range []string{"a", "b", "c"} {
+		small.Set(ctx, &entities.LLMCacheEntry{Key: key, Scope: "s", ExpiresAt: time.Now().Add(time.Hour)})
+	}
+	if _, ok, _ := small.Get(ctx, "a"); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	small.Set(ctx, &entities.LLMCacheEntry{Key: "old", Scope: "s", ExpiresAt: time.Now().Add(-time.Second)})
+	if _, ok, _ := small.Get(ctx, "old"); ok {
+		t.Error("expired entry was served")
+	}
+}
+
+// TestTriageUsesResponseCache verifies that repeated single-shot triage reuses the LLM reply
+// only for the same text
+func TestTriageUsesResponseCache(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+	inner := &MockLLMClient{}
+	inner.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "GREEN", "flags": []}`, nil)
+	client := llm.NewCachingClient(inner, llm.NewMemoryCacheStore(0), dto.LLMCacheConfig{TTL: time.Hour}, "test/model")
+	triageService := remedymate_services.NewTriageService(contentService, client, loadMessages(t), loadPrompts(t), dto.TriageConfig{})
+
+	classify := func(text string) {
+		t.Helper()
+		if _, err := triageService.ClassifySymptoms(context.Background(), text, "en", nil); err != nil {
+			t.Fatalf("ClassifySymptoms(%q) returned error: %v", text, err)
+		}
+	}
+	classify("I have a mild headache")
+	classify(" I have a mild  headache")
+	inner.AssertNumberOfCalls(t, "Generate", 1)
+
+	// The same words with different punctuation are triaged afresh
+	classify("I have a mild headache?")
+	inner.AssertNumberOfCalls(t, "Generate", 2)
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/infrastructure/llm"
+)
+
+// TestLLMProviderAdapters verifies that each adapter maps the request options and reads the reply
+func TestLLMProviderAdapters(t *testing.T) {
+	temperature := float32(0.7)
+	request := dto.PromptRequest("You are a triage classifier.", "I have a headache")
+	request.JSONMode = true
+	request.MaxTokens = 64
+	request.Temperature = &temperature
+	request.StopSequences = []string{"END"}
+
+	cases := []struct {
+		provider string
+		path     string
+		reply    string
+		check    func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			provider: llm.ProviderGemini,
+			path:     "/test-model:generateContent",
+			reply:    `{"candidates": [{"content": {"parts": [{"text": "{\"level\":\"GREEN\"}"}]}, "finishReason": "STOP"}], "usageMetadata": {"promptTokenCount": 12, "candidatesTokenCount": 5, "totalTokenCount": 17}}`,
+			check: func(t *testing.T, body map[string]interface{}) {
+				config := body["generationConfig"].(map[string]interface{})
+				if config["responseMimeType"] != "application/json" || config["maxOutputTokens"] != float64(64) {
+					t.Errorf("gemini generationConfig = %v", config)
+				}
+				if _, ok := body["systemInstruction"]; !ok {
+					t.Error("gemini request has no systemInstruction")
+				}
+			},
+		},
+		{
+			provider: llm.ProviderOpenAI,
+			path:     "/chat/completions",
+			reply:    `{"model": "test-model", "choices": [{"message": {"role": "assistant", "content": "{\"level\":\"GREEN\"}"}, "finish_reason": "stop"}], "usage": {"prompt_tokens": 12, "completion_tokens": 5, "total_tokens": 17}}`,
+			check: func(t *testing.T, body map[string]interface{}) {
+				messages := body["messages"].([]interface{})
+				if len(messages) != 2 || messages[0].(map[string]interface{})["role"] != "system" {
+					t.Errorf("openai messages = %v", messages)
+				}
+				if body["response_format"] == nil || body["max_tokens"] != float64(64) || body["stop"] == nil {
+					t.Errorf("openai options missing: %v", body)
+				}
+			},
+		},
+		{
+			provider: llm.ProviderOllama,
+			path:     "/api/chat",
+			reply:    `{"model": "test-model", "message": {"role": "assistant", "content": "{\"level\":\"GREEN\"}"}, "done_reason": "stop", "prompt_eval_count": 12, "eval_count": 5}`,
+			check: func(t *testing.T, body map[string]interface{}) {
+				options := body["options"].(map[string]interface{})
+				if body["format"] != "json" || body["stream"] != false || options["num_predict"] != float64(64) {
+					t.Errorf("ollama request = %v", body)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tc.path {
+					t.Errorf("path = %s, want %s", r.URL.Path, tc.path)
+				}
+				if strings.Contains(r.URL.RawQuery, "test-key") {
+					t.Error("API key sent in the URL")
+				}
+				if tc.provider == llm.ProviderGemini && r.Header.Get("x-goog-api-key") != "test-key" {
+					t.Errorf("gemini key header = %q", r.Header.Get("x-goog-api-key"))
+				}
+				raw, _ := io.ReadAll(r.Body)
+				var body map[string]interface{}
+				if err := json.Unmarshal(raw, &body); err != nil {
+					t.Fatalf("request is not JSON: %v", err)
+				}
+				tc.check(t, body)
+				w.Write([]byte(tc.reply))
+			}))
+			defer server.Close()
+
+			client, err := llm.NewClient(dto.LLMConfig{
+				Provider: tc.provider,
+				APIKey:   "test-key",
+				Model:    "test-model",
+				BaseURL:  server.URL,
+				Timeout:  5,
+			})
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			resp, err := client.Generate(context.Background(), request)
+			if err != nil {
+				t.Fatalf("Generate returned error: %v", err)
+			}
+			if resp.Text != `{"level":"GREEN"}` || resp.Usage.TotalTokens != 17 || resp.FinishReason == "" {
+				t.Errorf("unexpected response %+v", resp)
+			}
+		})
+	}
+
+	// Errors are stored and shown to admins, so they must not carry credentials from the URL
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client, _ := llm.NewClient(dto.LLMConfig{
+		Provider: llm.ProviderGemini,
+		APIKey:   "test-key",
+		Model:    "test-model",
+		BaseURL:  strings.Replace(server.URL, "http://", "http://user:secret@", 1),
+		Timeout:  5,
+	})
+	if _, err := client.Generate(context.Background(), request); err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("transport error = %v", err)
+	}
+}
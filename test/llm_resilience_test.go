@@ -0,0 +1,172 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/infrastructure/llm"
+)
+
+const openAIReply = `{"model": "test-model", "choices": [{"message": {"role": "assistant", "content": "ok"}, "finish_reason": "stop"}]}`
+
+// newStandInProvider starts a local OpenAI-compatible server whose replies are decided by handle
+func newStandInProvider(t *testing.T, handle func(hit int32, w http.ResponseWriter)) (*httptest.Server, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(atomic.AddInt32(&hits, 1), w)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func newResilientTestClient(t *testing.T, baseURL string, config dto.LLMResilienceConfig) *llm.ResilientClient {
+	inner, err := llm.NewClient(dto.LLMConfig{Provider: llm.ProviderOpenAI, Model: "test-model", BaseURL: baseURL, Timeout: 5})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return llm.NewResilientClient(inner, config)
+}
+
+// TestResilientLLMClient verifies retries, Retry-After, the circuit breaker and the concurrency cap
+func TestResilientLLMClient(t *testing.T) {
+	request := dto.PromptRequest("system", "hello")
+	fast := dto.LLMResilienceConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		server, hits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+			if hit < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(openAIReply))
+		})
+		resp, err := newResilientTestClient(t, server.URL, fast).Generate(context.Background(), request)
+		if err != nil || resp.Text != "ok" {
+			t.Fatalf("Generate = %v, %v; want ok", resp, err)
+		}
+		if *hits != 3 {
+			t.Errorf("provider hit %d times, want 3", *hits)
+		}
+	})
+
+	t.Run("honours Retry-After without holding a slot", func(t *testing.T) {
+		server, hits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+			if hit == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(openAIReply))
+		})
+		client := newResilientTestClient(t, server.URL, dto.LLMResilienceConfig{MaxRetries: 2, MaxDelay: 2 * time.Second, MaxConcurrent: 1})
+		start := time.Now()
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.Generate(context.Background(), request)
+			done <- err
+		}()
+		for atomic.LoadInt32(hits) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		// The only slot is free while the first call waits out Retry-After
+		if _, err := client.Generate(context.Background(), request); err != nil || time.Since(start) > 500*time.Millisecond {
+			t.Errorf("second call returned %v after %s", err, time.Since(start))
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+			t.Errorf("retried after %s, want at least the 1s Retry-After", elapsed)
+		}
+	})
+
+	t.Run("gives up on Retry-After beyond MaxDelay", func(t *testing.T) {
+		server, hits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		start := time.Now()
+		_, err := newResilientTestClient(t, server.URL, fast).Generate(context.Background(), request)
+		if !errors.Is(err, derrors.ErrLLMDegraded) || time.Since(start) > time.Second {
+			t.Errorf("Generate = %v after %s, want ErrLLMDegraded at once", err, time.Since(start))
+		}
+		if *hits != 1 {
+			t.Errorf("provider hit %d times, want 1", *hits)
+		}
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		server, hits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+		_, err := newResilientTestClient(t, server.URL, fast).Generate(context.Background(), request)
+		var providerErr *llm.ProviderError
+		if !errors.As(err, &providerErr) || providerErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("error = %v, want the provider's 400", err)
+		}
+		if *hits != 1 {
+			t.Errorf("provider hit %d times, want 1", *hits)
+		}
+	})
+
+	t.Run("circuit opens and fails fast", func(t *testing.T) {
+		server, hits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		config := dto.LLMResilienceConfig{FailureThreshold: 2, OpenDuration: time.Hour}
+		client := newResilientTestClient(t, server.URL, config)
+		for i := 0; i < 2; i++ {
+			if _, err := client.Generate(context.Background(), request); !errors.Is(err, derrors.ErrLLMDegraded) {
+				t.Fatalf("call %d error = %v, want ErrLLMDegraded", i+1, err)
+			}
+		}
+		if client.State() != llm.CircuitOpen {
+			t.Fatalf("State = %s, want open", client.State())
+		}
+		if _, err := client.Generate(context.Background(), request); !errors.Is(err, derrors.ErrLLMDegraded) {
+			t.Errorf("error = %v, want ErrLLMDegraded", err)
+		}
+		if *hits != 2 {
+			t.Errorf("provider hit %d times with the circuit open, want 2", *hits)
+		}
+	})
+
+	t.Run("caps concurrent calls", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		server, _ := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte(openAIReply))
+		})
+		client := newResilientTestClient(t, server.URL, dto.LLMResilienceConfig{MaxConcurrent: 1})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Generate(context.Background(), request); err != nil {
+					t.Errorf("Generate returned error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if maxInFlight != 1 {
+			t.Errorf("max in-flight calls = %d, want 1", maxInFlight)
+		}
+	})
+}
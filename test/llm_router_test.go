@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"remedymate-backend/config"
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/infrastructure/llm"
+)
+
+// TestLLMRouterFailover verifies failover on errors and timeouts, skipping providers with an
+// open circuit, provider stamping and the health report
+func TestLLMRouterFailover(t *testing.T) {
+	request := dto.PromptRequest("system", "hello")
+	resilience := dto.LLMResilienceConfig{FailureThreshold: 2, OpenDuration: time.Minute}
+
+	down, downHits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	slow, _ := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte(openAIReply))
+	})
+	up, upHits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+		w.Write([]byte(openAIReply))
+	})
+
+	router := llm.NewRouterClient([]dto.LLMConfig{
+		{Name: "primary", Provider: llm.ProviderOpenAI, Model: "big", BaseURL: down.URL, Timeout: 5},
+		{Provider: llm.ProviderGemini, Model: "gemini-2.0-flash"}, // no API key
+		{Name: "slow", Provider: llm.ProviderOpenAI, Model: "small", BaseURL: slow.URL, Timeout: 5},
+		{Name: "local", Provider: llm.ProviderOpenAI, Model: "local", BaseURL: up.URL, Timeout: 5},
+	}, resilience, dto.LLMRouterConfig{AttemptTimeout: 50 * time.Millisecond})
+
+	for i := 0; i < 3; i++ {
+		resp, err := router.Generate(context.Background(), request)
+		if err != nil {
+			t.Fatalf("call %d: Generate returned error: %v", i, err)
+		}
+		if resp.Text != "ok" || resp.Provider != "local" {
+			t.Errorf("call %d: served by %q, want local", i, resp.Provider)
+		}
+	}
+	// The primary's circuit opened after two failures, so the third call skipped it
+	if got := atomic.LoadInt32(downHits); got != 2 {
+		t.Errorf("failing primary hit %d times, want 2", got)
+	}
+	if got := atomic.LoadInt32(upHits); got != 3 {
+		t.Errorf("fallback hit %d times, want 3", got)
+	}
+
+	statuses := router.ProviderStatus()
+	if len(statuses) != 4 {
+		t.Fatalf("ProviderStatus returned %d providers, want 4", len(statuses))
+	}
+	primary, gemini, slowStatus, local := statuses[0], statuses[1], statuses[2], statuses[3]
+	if primary.Live || primary.Circuit != llm.CircuitOpen || primary.Failures != 2 {
+		t.Errorf("unexpected primary status %+v", primary)
+	}
+	if gemini.Available || gemini.Live || gemini.Name != "gemini/gemini-2.0-flash" || gemini.Reason == "" {
+		t.Errorf("provider without an API key should be unavailable: %+v", gemini)
+	}
+	// Timeouts count against the provider too, so its circuit opened after two
+	if slowStatus.Live || slowStatus.Circuit != llm.CircuitOpen || slowStatus.Failures != 2 || slowStatus.LastError == "" {
+		t.Errorf("timed out provider status %+v, want an open circuit after 2 failures", slowStatus)
+	}
+	if !local.Live || local.Calls != 3 || local.Failures != 0 || local.LastSuccessAt == nil || local.Priority != 4 {
+		t.Errorf("unexpected fallback status %+v", local)
+	}
+	if live := router.Live(); len(live) != 1 || live[0] != "local" {
+		t.Errorf("Live = %v, want [local]", live)
+	}
+}
+
+// TestLLMRouterWithoutProviders verifies the service can run with no usable provider
+func TestLLMRouterWithoutProviders(t *testing.T) {
+	router := llm.NewRouterClient([]dto.LLMConfig{{Provider: llm.ProviderGemini}}, dto.LLMResilienceConfig{}, dto.LLMRouterConfig{})
+	if _, err := router.Generate(context.Background(), dto.PromptRequest("system", "hello")); !errors.Is(err, derrors.ErrLLMDegraded) {
+		t.Errorf("Generate without providers = %v, want ErrLLMDegraded", err)
+	}
+	if statuses := router.ProviderStatus(); len(statuses) != 1 || statuses[0].Available || len(router.Live()) != 0 {
+		t.Errorf("unexpected provider status %+v", statuses)
+	}
+}
+
+// TestLLMProviderConfigs verifies LLM_PROVIDERS parsing and per-provider credentials
+func TestLLMProviderConfigs(t *testing.T) {
+	t.Setenv("LLM_PROVIDERS", "gemini:gemini-2.0-flash, openai:gpt-4o-mini ,ollama:llama3.1")
+	t.Setenv("GEMINI_API_KEY", "gemini-key")
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OLLAMA_BASE_URL", "http://ollama:11434")
+	configs, err := config.LoadLLMProviderConfigs()
+	if err != nil {
+		t.Fatalf("LoadLLMProviderConfigs returned error: %v", err)
+	}
+	if len(configs) != 3 || configs[0].Name != "gemini/gemini-2.0-flash" || configs[0].APIKey != "gemini-key" ||
+		configs[1].Model != "gpt-4o-mini" || configs[2].BaseURL != "http://ollama:11434" {
+		t.Errorf("unexpected provider configs %+v", configs)
+	}
+
+	for _, list := range []string{"gemini", "gemini:a,gemini:a", "openai:"} {
+		t.Setenv("LLM_PROVIDERS", list)
+		if _, err := config.LoadLLMProviderConfigs(); err == nil {
+			t.Errorf("LLM_PROVIDERS=%q accepted", list)
+		}
+	}
+
+	// Without a list or a Gemini key the single legacy provider is returned for the router to report
+	t.Setenv("LLM_PROVIDERS", "")
+	t.Setenv("LLM_PROVIDER", "")
+	t.Setenv("LLM_MODEL", "")
+	t.Setenv("LLM_API_KEY", "")
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GEMINI_MODEL", "gemini-2.0-flash")
+	configs, err = config.LoadLLMProviderConfigs()
+	if err != nil || len(configs) != 1 || configs[0].Provider != llm.ProviderGemini || configs[0].APIKey != "" {
+		t.Errorf("legacy configuration = %+v, %v", configs, err)
+	}
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/llm"
+	"remedymate-backend/infrastructure/remedymate_services"
+)
+
+// memoryUsageRepo keeps usage records in a slice
+type memoryUsageRepo struct {
+	mu      sync.Mutex
+	records []entities.LLMUsageRecord
+}
+
+func (r *memoryUsageRepo) Create(ctx context.Context, record *entities.LLMUsageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, *record)
+	return nil
+}
+
+func (r *memoryUsageRepo) DailyAggregates(ctx context.Context, filter dto.LLMUsageFilter) ([]dto.LLMUsageDaily, error) {
+	return nil, nil
+}
+
+func (r *memoryUsageRepo) TotalTokensSince(ctx context.Context, since time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var total int64
+	for _, rec := range r.records {
+		if !rec.CreatedAt.Before(since) {
+			total += int64(rec.TotalTokens)
+		}
+	}
+	return total, nil
+}
+
+// TestLLMUsageAccounting verifies per-call records from provider usage metadata and the daily budget
+func TestLLMUsageAccounting(t *testing.T) {
+	server, hits := newStandInProvider(t, func(hit int32, w http.ResponseWriter) {
+		if hit == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"model": "test-model-0613", "choices": [{"message": {"role": "assistant", "content": "{\"topic_key\": \"headache\"}"}, "finish_reason": "stop"}], "usage": {"prompt_tokens": 30, "completion_tokens": 10, "total_tokens": 40}}`))
+	})
+	inner, err := llm.NewClient(dto.LLMConfig{Provider: llm.ProviderOpenAI, Model: "test-model", BaseURL: server.URL, Timeout: 5})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	repo := &memoryUsageRepo{}
+	client := llm.NewUsageClient(inner, repo, dto.LLMBudgetConfig{DailyTokens: 100}, llm.ProviderOpenAI, "test-model")
+	mapService := remedymate_services.NewMapTopicService(client, loadPrompts(t))
+
+	// Calls are attributed to the calling feature; failures are kept with no tokens
+	for i := 0; i < 4; i++ {
+		mapService.MapSymptomToTopic(context.Background(), "I have a headache", []string{"headache"})
+	}
+	if len(repo.records) != 4 {
+		t.Fatalf("recorded %d calls, want 4", len(repo.records))
+	}
+	first := repo.records[0]
+	if first.Feature != dto.LLMFeatureMapTopic || first.Model != "test-model-0613" || first.TotalTokens != 40 || !first.Success {
+		t.Errorf("unexpected record %+v", first)
+	}
+	if failed := repo.records[1]; failed.Success || failed.TotalTokens != 0 || failed.Error == "" {
+		t.Errorf("failed call recorded as %+v", failed)
+	}
+
+	// 3 successful calls used 120 of the 100 token budget, so further calls fail fast
+	_, err = client.Generate(context.Background(), dto.PromptRequest("system", "hello"))
+	if !errors.Is(err, derrors.ErrLLMDegraded) {
+		t.Errorf("error over budget = %v, want ErrLLMDegraded", err)
+	}
+	if *hits != 4 {
+		t.Errorf("provider hit %d times, want 4", *hits)
+	}
+	status := client.BudgetStatus()
+	if !status.Exceeded || status.UsedTokens != 120 || status.RefusedCalls != 1 {
+		t.Errorf("unexpected budget status %+v", status)
+	}
+
+	// A restart picks up today's usage from the repository
+	restarted := llm.NewUsageClient(inner, repo, dto.LLMBudgetConfig{DailyTokens: 100}, llm.ProviderOpenAI, "test-model")
+	if err := restarted.Restore(context.Background()); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if status := restarted.BudgetStatus(); status.UsedTokens != 120 || !status.Exceeded {
+		t.Errorf("restored budget status %+v", status)
+	}
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/llmoutput"
+)
+
+// TestLLMOutputDecode covers the response shapes we see from LLM providers
+func TestLLMOutputDecode(t *testing.T) {
+	type triage struct {
+		Level string   `json:"level"`
+		Flags []string `json:"flags"`
+	}
+	schema := llmoutput.Schema{
+		Required: []string{"level"},
+		Enum:     map[string][]string{"level": {"RED", "YELLOW", "GREEN", "UNCLEAR"}},
+	}
+
+	var fenced triage
+	if err := llmoutput.Decode("Sure! Here it is:\n```json\n{\"level\": \"RED\", \"flags\": [\"chest pain\"]}\n```", &fenced, schema); err != nil {
+		t.Fatalf("fenced response: %v", err)
+	}
+	if fenced.Level != "RED" || len(fenced.Flags) != 1 {
+		t.Errorf("fenced response decoded as %+v", fenced)
+	}
+
+	var truncated triage
+	if err := llmoutput.Decode(`{"level": "YELLOW", "flags": ["high fever", "sti`, &truncated, schema); err != nil {
+		t.Fatalf("truncated object: %v", err)
+	}
+	if truncated.Level != "YELLOW" {
+		t.Errorf("truncated object level = %q, want YELLOW", truncated.Level)
+	}
+
+	var questions []entities.Question
+	err := llmoutput.Decode(`[{"id": 1, "text": "When did it start?", "type": "duration"},
+		{"id": 2, "text": "Where does it hurt?", "type": "location"},
+		{"id": 3, "text": "How bad is it?", "type": "severity"},
+		{"id": 4, "text": "Any other sym`, &questions, llmoutput.Schema{MinItems: 3})
+	if err != nil {
+		t.Fatalf("truncated array: %v", err)
+	}
+	if len(questions) != 3 {
+		t.Errorf("truncated array kept %d questions, want 3", len(questions))
+	}
+
+	cases := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{name: "refusal", raw: "I'm sorry, but I can't help with that request.", want: llmoutput.ErrRefusal},
+		{name: "no json", raw: "level: red", want: llmoutput.ErrMalformed},
+		{name: "unknown level", raw: `{"level": "ORANGE", "flags": []}`, want: llmoutput.ErrSchemaViolation},
+		{name: "missing level", raw: `{"flags": []}`, want: llmoutput.ErrSchemaViolation},
+		{name: "wrong type", raw: `{"level": "RED", "flags": "chest pain"}`, want: llmoutput.ErrSchemaViolation},
+	}
+	for _, tc := range cases {
+		var out triage
+		err := llmoutput.Decode(tc.raw, &out, schema)
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: error = %v, want %v", tc.name, err, tc.want)
+		}
+		var parseErr *llmoutput.ParseError
+		if err != nil && (!errors.As(err, &parseErr) || parseErr.Raw != tc.raw) {
+			t.Errorf("%s: error does not carry the raw response", tc.name)
+		}
+	}
+}
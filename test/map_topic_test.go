@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/llm"
+	"remedymate-backend/infrastructure/remedymate_services"
+	"remedymate-backend/usecase"
+)
+
+// TestMapTopicWithFakeClient verifies topic mapping against scripted LLM replies
+func TestMapTopicWithFakeClient(t *testing.T) {
+	client := llm.NewScriptedClient([]llm.ScriptedResponse{
+		{Match: "pounding head", Response: "```json\n{\"topic_key\": \"headache\"}\n```"},
+		{Match: "broken phone", Response: `{"topic_key": "DOES NOT FIT IN ANY TOPIC"}`},
+		{Match: "made up topic", Response: `{"topic_key": "toothache"}`},
+		{Match: "rambling", Response: `I think this is about indigestion`},
+		{Match: "provider down", Error: "connection refused"},
+	}, "")
+	mapService := remedymate_services.NewMapTopicService(client, loadPrompts(t))
+	topics := []string{"headache", "indigestion"}
+
+	key, err := mapService.MapSymptomToTopic(context.Background(), "I have a pounding head", topics)
+	if err != nil || key != "headache" {
+		t.Errorf("MapSymptomToTopic = %q, %v; want headache", key, err)
+	}
+	key, err = mapService.MapSymptomToTopic(context.Background(), "my broken phone", topics)
+	if err != nil || key != "" {
+		t.Errorf("MapSymptomToTopic = %q, %v; want no topic", key, err)
+	}
+	if _, err := mapService.MapSymptomToTopic(context.Background(), "rambling answer", topics); err == nil {
+		t.Error("expected an error for a reply without JSON")
+	}
+	if _, err := mapService.MapSymptomToTopic(context.Background(), "provider down", topics); err == nil {
+		t.Error("expected an error when the LLM call fails")
+	}
+
+	// The usecase turns "no topic" into ErrNoTopicMapped and rejects unknown keys
+	remedyUsecase := usecase.NewRemedyMateUsecase(nil, content.NewContentService("../data", nil, nil), nil, mapService, nil, nil)
+	if _, err := remedyUsecase.MapTopic(context.Background(), "my broken phone"); !errors.Is(err, derrors.ErrNoTopicMapped) {
+		t.Errorf("MapTopic error = %v, want ErrNoTopicMapped", err)
+	}
+	if _, err := remedyUsecase.MapTopic(context.Background(), "a made up topic"); err == nil {
+		t.Error("expected an error for a topic key outside the list")
+	}
+}
@@ -0,0 +1,310 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/bootstrap"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryTopicRepo is an in-memory TopicRepository
+type memoryTopicRepo struct {
+	mu     sync.Mutex
+	topics map[string]entities.Topic
+}
+
+func newMemoryTopicRepo() *memoryTopicRepo {
+	return &memoryTopicRepo{topics: make(map[string]entities.Topic)}
+}
+
+func (r *memoryTopicRepo) CreateTopic(ctx context.Context, topic *entities.Topic) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.topics[topic.TopicKey]; exists {
+		return derrors.ErrTopicAlreadyExists
+	}
+	if topic.Status == "" {
+		topic.Status = entities.TopicStatusActive
+	}
+	r.topics[topic.TopicKey] = *topic
+	return nil
+}
+
+func (r *memoryTopicRepo) GetTopicByKey(ctx context.Context, topicKey string) (*entities.Topic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	topic, ok := r.topics[topicKey]
+	if !ok || topic.Status == entities.TopicStatusDeleted {
+		return nil, derrors.ErrTopicNotFound
+	}
+	return &topic, nil
+}
+
+func (r *memoryTopicRepo) GetTopicIncludingDeleted(ctx context.Context, topicKey string) (*entities.Topic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	topic, ok := r.topics[topicKey]
+	if !ok {
+		return nil, derrors.ErrTopicNotFound
+	}
+	return &topic, nil
+}
+
+func (r *memoryTopicRepo) ListAllTopics(ctx context.Context, params dto.TopicListQueryParams) ([]*entities.Topic, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var topics []*entities.Topic
+	for _, topic := range r.topics {
+		if topic.Status != entities.TopicStatusDeleted && (params.State == "" || string(topic.State) == params.State) {
+			topic := topic
+			topics = append(topics, &topic)
+		}
+	}
+	return topics, int64(len(topics)), nil
+}
+
+func (r *memoryTopicRepo) ListPublishedTopics(ctx context.Context) ([]*entities.Topic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var topics []*entities.Topic
+	for _, topic := range r.topics {
+		if topic.Status != entities.TopicStatusDeleted && topic.State != entities.TopicStateArchived &&
+			(topic.PublishedAt != nil || topic.State == "") {
+			topic := topic
+			topics = append(topics, &topic)
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].TopicKey < topics[j].TopicKey })
+	return topics, nil
+}
+
+func (r *memoryTopicRepo) UpdateTopic(ctx context.Context, topicKey string, update *entities.Topic) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.topics[topicKey]; !ok {
+		return derrors.ErrTopicNotFound
+	}
+	r.topics[topicKey] = *update
+	return nil
+}
+
+func (r *memoryTopicRepo) DeleteTopic(ctx context.Context, topicKey string, deletedByUserID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	topic, ok := r.topics[topicKey]
+	if !ok || topic.Status == entities.TopicStatusDeleted {
+		return derrors.ErrTopicNotFound
+	}
+	topic.Status = entities.TopicStatusDeleted
+	topic.UpdatedAt = time.Now()
+	r.topics[topicKey] = topic
+	return nil
+}
+
+func (r *memoryTopicRepo) CheckTopicExists(ctx context.Context, topicKey string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.topics[topicKey]
+	return ok, nil
+}
+
+// adminContext returns a context for a new admin user
+func adminContext() context.Context {
+	return context.WithValue(context.Background(), "userID", primitive.NewObjectID().Hex())
+}
+
+// transition moves a topic to the given state and fails the test on error
+func transition(t *testing.T, ctx context.Context, topics *usecase.TopicUsecase, key string, to entities.TopicState, notes string) *entities.Topic {
+	t.Helper()
+	topic, err := topics.TransitionTopic(ctx, key, dto.TopicTransitionRequest{To: to, Notes: notes})
+	if err != nil {
+		t.Fatalf("%s to %s: %v", key, to, err)
+	}
+	return topic
+}
+
+// TestContentFromTopicCollection verifies that topics are seeded from the JSON file, served from
+// the collection, and that published changes reach content, mapping keys and the local mapper at once
+func TestContentFromTopicCollection(t *testing.T) {
+	repo := newMemoryTopicRepo()
+	if err := bootstrap.SeedTopics(repo, newMemoryTopicRevisionRepo(), "../data"); err != nil {
+		t.Fatalf("SeedTopics returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", nil, repo)
+	seeded := len(contentService.TopicKeys())
+	if seeded == 0 {
+		t.Fatal("no topics seeded")
+	}
+	if _, err := contentService.GetContentByTopic("headache", "am"); err != nil {
+		t.Errorf("seeded headache content: %v", err)
+	}
+
+	topics := usecase.NewTopicUsecase(repo, newMemoryTopicRevisionRepo(), contentService.(*content.ContentService), dto.TopicPublishConfig{})
+	author, reviewer := adminContext(), adminContext()
+	guidance := entities.LocalizedGuidanceContent{
+		SelfCare:   []string{"Sip cold water slowly", "Hold your breath for a few seconds"},
+		SeekCareIf: []string{"Hiccups last more than two days"},
+		Disclaimer: "This is general information, not medical advice.",
+	}
+	_, err := topics.CreateTopic(author, dto.TopicCreateRequest{
+		TopicKey:     "hiccups",
+		NameEN:       "Hiccups",
+		NameAM:       "ሳግ",
+		Synonyms:     map[string][]string{"en": {"I keep hiccuping"}},
+		Translations: map[string]entities.LocalizedGuidanceContent{"en": guidance, "am": guidance},
+	})
+	if err != nil {
+		t.Fatalf("CreateTopic returned error: %v", err)
+	}
+	transition(t, author, topics, "hiccups", entities.TopicStateInReview, "")
+	transition(t, reviewer, topics, "hiccups", entities.TopicStateApproved, "")
+	transition(t, reviewer, topics, "hiccups", entities.TopicStatePublished, "")
+
+	// The new topic is served and mappable without a restart
+	if got, err := contentService.GetContentByTopic("hiccups", "en"); err != nil || got.SelfCare[0] != guidance.SelfCare[0] {
+		t.Errorf("GetContentByTopic(hiccups) = %+v, %v", got, err)
+	}
+	mapper := newLocalTopicMapper(t, contentService)
+	local := usecase.NewRemedyMateUsecase(nil, contentService, nil, nil, mapper, nil)
+	if key, err := local.MapTopic(context.Background(), "I have had hiccups all day"); err != nil || key != "hiccups" {
+		t.Errorf("MapTopic = %q, %v; want hiccups", key, err)
+	}
+
+	// Deletions are picked up too
+	if err := topics.SoftDeleteTopic(author, "headache"); err != nil {
+		t.Fatalf("SoftDeleteTopic returned error: %v", err)
+	}
+	if _, err := contentService.GetContentByTopic("headache", "en"); !errors.Is(err, derrors.ErrTopicNotFound) {
+		t.Errorf("deleted topic still served: %v", err)
+	}
+	deleted := repo.topics["headache"]
+	if last := deleted.Transitions[len(deleted.Transitions)-1]; last.To != entities.TopicStateArchived || last.Actor.IsZero() || last.At.IsZero() {
+		t.Errorf("deletion recorded as %+v, want a transition to archived with actor and time", last)
+	}
+	if got := len(contentService.TopicKeys()); got != seeded {
+		t.Errorf("%d topics served, want %d", got, seeded)
+	}
+
+	// Seeding again leaves admin changes alone
+	if err := bootstrap.SeedTopics(repo, newMemoryTopicRevisionRepo(), "../data"); err != nil {
+		t.Fatalf("SeedTopics returned error: %v", err)
+	}
+	if topic, _ := repo.GetTopicByKey(context.Background(), "headache"); topic != nil {
+		t.Error("seeding restored a deleted topic")
+	}
+
+	// Taking down the last served topics clears them instead of keeping the previous set
+	for _, key := range contentService.TopicKeys() {
+		if err := repo.DeleteTopic(context.Background(), key, ""); err != nil {
+			t.Fatalf("DeleteTopic(%s) returned error: %v", key, err)
+		}
+	}
+	if err := contentService.(*content.ContentService).RefreshTopics(context.Background()); err != nil {
+		t.Fatalf("RefreshTopics returned error: %v", err)
+	}
+	if keys := contentService.TopicKeys(); len(keys) != 0 {
+		t.Errorf("%d topics still served after all were deleted", len(keys))
+	}
+}
+
+// TestTopicEditorialWorkflow verifies that drafts are not served, authors cannot approve their
+// own changes, published content changes only on publication, and archiving stops serving
+func TestTopicEditorialWorkflow(t *testing.T) {
+	repo := newMemoryTopicRepo()
+	if err := bootstrap.SeedTopics(repo, newMemoryTopicRevisionRepo(), "../data"); err != nil {
+		t.Fatalf("SeedTopics returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", nil, repo)
+	topics := usecase.NewTopicUsecase(repo, newMemoryTopicRevisionRepo(), contentService.(*content.ContentService), dto.TopicPublishConfig{})
+	author, reviewer := adminContext(), adminContext()
+
+	live, _ := contentService.GetContentByTopic("cough", "en")
+	edited := entities.LocalizedGuidanceContent{SelfCare: []string{"Drink warm fluids"}, SeekCareIf: live.SeekCareIf, Disclaimer: live.Disclaimer}
+	am := repoTopic(t, repo, "cough").Translations["am"]
+	update := dto.TopicUpdateRequest{Translations: map[string]entities.LocalizedGuidanceContent{"en": edited, "am": am}}
+	topic, err := topics.UpdateTopic(author, "cough", update)
+	if err != nil {
+		t.Fatalf("UpdateTopic returned error: %v", err)
+	}
+	if topic.State != entities.TopicStateDraft || topic.Draft == nil || topic.Translations["en"].SelfCare[0] == "Drink warm fluids" {
+		t.Fatalf("edit did not go to a draft: state %s", topic.State)
+	}
+	if got, _ := contentService.GetContentByTopic("cough", "en"); got.SelfCare[0] != live.SelfCare[0] {
+		t.Error("draft served before publication")
+	}
+
+	// Publishing needs a review by someone who did not write the change
+	if _, err := topics.TransitionTopic(author, "cough", dto.TopicTransitionRequest{To: entities.TopicStatePublished}); !errors.Is(err, derrors.ErrInvalidTopicTransition) {
+		t.Errorf("publishing a draft: %v, want ErrInvalidTopicTransition", err)
+	}
+	transition(t, author, topics, "cough", entities.TopicStateInReview, "")
+	if _, err := topics.TransitionTopic(author, "cough", dto.TopicTransitionRequest{To: entities.TopicStateApproved}); !errors.Is(err, derrors.ErrSelfReview) {
+		t.Errorf("self-approval: %v, want ErrSelfReview", err)
+	}
+	if _, err := topics.TransitionTopic(reviewer, "cough", dto.TopicTransitionRequest{To: entities.TopicStateDraft}); !errors.Is(err, derrors.ErrInvalidInput) {
+		t.Errorf("sending back without notes: %v, want ErrInvalidInput", err)
+	}
+	transition(t, reviewer, topics, "cough", entities.TopicStateApproved, "checked against the guideline")
+	if got, _ := contentService.GetContentByTopic("cough", "en"); got.SelfCare[0] != live.SelfCare[0] {
+		t.Error("approved change served before publication")
+	}
+
+	topic = transition(t, author, topics, "cough", entities.TopicStatePublished, "warm fluids advice")
+	if got, _ := contentService.GetContentByTopic("cough", "en"); got.SelfCare[0] != "Drink warm fluids" {
+		t.Errorf("published change not served: %v", got.SelfCare)
+	}
+	if topic.Draft != nil || topic.Version != 2 || topic.PublishedAt == nil || len(topic.RevisionHistory) != 1 {
+		t.Errorf("unexpected published topic: version %d, draft %v", topic.Version, topic.Draft != nil)
+	}
+	var path []entities.TopicState
+	for _, tr := range topic.Transitions {
+		if tr.At.IsZero() {
+			t.Errorf("transition to %s has no timestamp", tr.To)
+		}
+		path = append(path, tr.To)
+	}
+	want := []entities.TopicState{"published", "draft", "in_review", "approved", "published"}
+	if len(path) != len(want) {
+		t.Fatalf("transitions = %v, want %v", path, want)
+	}
+	for i := range want {
+		if path[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", path, want)
+		}
+	}
+
+	// Archiving stops serving; reopening needs another review before it is served again
+	transition(t, reviewer, topics, "cough", entities.TopicStateArchived, "")
+	if _, err := contentService.GetContentByTopic("cough", "en"); !errors.Is(err, derrors.ErrTopicNotFound) {
+		t.Errorf("archived topic still served: %v", err)
+	}
+	if _, err := topics.UpdateTopic(author, "cough", dto.TopicUpdateRequest{NameEN: "Cough"}); err != nil {
+		t.Fatalf("UpdateTopic returned error: %v", err)
+	}
+	if _, err := contentService.GetContentByTopic("cough", "en"); !errors.Is(err, derrors.ErrTopicNotFound) {
+		t.Errorf("archived topic served again after an edit: %v", err)
+	}
+	if queue, _ := topics.ListAllTopics(reviewer, dto.TopicListQueryParams{State: "draft"}); queue.TotalCount != 1 {
+		t.Errorf("draft queue has %d topics, want 1", queue.TotalCount)
+	}
+}
+
+func repoTopic(t *testing.T, repo *memoryTopicRepo, key string) *entities.Topic {
+	t.Helper()
+	topic, err := repo.GetTopicByKey(context.Background(), key)
+	if err != nil {
+		t.Fatalf("GetTopicByKey(%s): %v", key, err)
+	}
+	return topic
+}
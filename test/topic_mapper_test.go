@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/lexical"
+	"remedymate-backend/infrastructure/llm"
+	"remedymate-backend/infrastructure/remedymate_services"
+	"remedymate-backend/usecase"
+)
+
+var allTopics = []string{"indigestion", "headache", "sore_throat", "cough", "fever", "back_pain"}
+
+func newLocalTopicMapper(t *testing.T, contentService interfaces.ContentService) *remedymate_services.LocalTopicMapper {
+	mapper, err := remedymate_services.NewLocalTopicMapper(contentService,
+		dto.TopicMapperConfig{Mode: dto.TopicMapperHybrid, MinScore: 2.5, MinMargin: 0.3})
+	if err != nil {
+		t.Fatalf("NewLocalTopicMapper returned error: %v", err)
+	}
+	return mapper
+}
+
+// TestAmharicTokenize verifies that inflected Amharic words reduce to the same term
+func TestAmharicTokenize(t *testing.T) {
+	head := lexical.Tokenize("ራስ")
+	for _, word := range []string{"ራሴን", "ራሱ"} {
+		if terms := lexical.Tokenize(word); len(terms) == 0 || terms[0] != head[0] {
+			t.Errorf("Tokenize(%q) = %v, want %v", word, terms, head)
+		}
+	}
+	if terms := lexical.Tokenize("I have a pounding headache, and it hurts!"); len(terms) != 2 {
+		t.Errorf("English stopwords not removed: %v", terms)
+	}
+}
+
+// TestLocalTopicMapper verifies English and Amharic ranking and when a match is confident
+func TestLocalTopicMapper(t *testing.T) {
+	mapper := newLocalTopicMapper(t, content.NewContentService("../data", nil, nil))
+
+	confident := map[string]string{
+		"my head is pounding":                 "headache",
+		"it hurts when I swallow":             "sore_throat",
+		"I keep coughing up phlegm":           "cough",
+		"my lower back hurts after lifting":   "back_pain",
+		"my stomach feels upset after eating": "indigestion",
+		"ራሴን ያመኛል":                            "headache",
+		"ጉሮሮዬን ያመኛል":                          "sore_throat",
+		"ትኩሳት አለኝ":                            "fever",
+	}
+	for input, want := range confident {
+		ranking := mapper.Rank(input, allTopics)
+		if !ranking.Confident || ranking.Candidates[0].TopicKey != want {
+			t.Errorf("Rank(%q) = %+v, want confident %s", input, ranking, want)
+		}
+	}
+
+	// Two symptoms: both ranked, neither confident
+	ranking := mapper.Rank("I have a dry cough and sore throat", allTopics)
+	if ranking.Confident || !ranking.Usable || len(ranking.Candidates) < 2 {
+		t.Errorf("multi-symptom ranking = %+v, want usable but not confident", ranking)
+	}
+	// Words found only in a topic's advice do not name it
+	if ranking := mapper.Rank("my knee hurts", allTopics); ranking.Usable {
+		t.Errorf("Rank(knee) = %+v, want not usable", ranking)
+	}
+	if ranking := mapper.Rank("my broken phone", allTopics); ranking.Usable || len(ranking.Candidates) != 0 {
+		t.Errorf("Rank(phone) = %+v, want no candidates", ranking)
+	}
+	// Only the offered topics are ranked
+	if ranking := mapper.Rank("my head is pounding", []string{"fever", "cough"}); len(ranking.Candidates) != 0 {
+		t.Errorf("Rank outside the topic list = %+v", ranking)
+	}
+}
+
+// TestApprovedBlockLanguages verifies that topic names and descriptions are read for any
+// language code in approved_block.json
+func TestApprovedBlockLanguages(t *testing.T) {
+	blocks, err := content.LoadBlockFile("../data/approved_block.json")
+	if err != nil {
+		t.Fatalf("LoadBlockFile returned error: %v", err)
+	}
+	for _, block := range blocks {
+		if block.TopicKey == "headache" && (block.Names["en"] == "" || block.Names["am"] == "" || block.Descriptions["am"] == "") {
+			t.Errorf("headache names %v, descriptions %v; want en and am", block.Names, block.Descriptions)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "approved_block.json")
+	file := `[{"topic_key": "headache", "name_en": "Headache", "name_om": "Mataa bowwuu", "description_om": "", "translations": {}}]`
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blocks, err = content.LoadBlockFile(path)
+	if err != nil {
+		t.Fatalf("LoadBlockFile returned error: %v", err)
+	}
+	if names := blocks[0].Names; len(names) != 2 || names["om"] != "Mataa bowwuu" || len(blocks[0].Descriptions) != 0 {
+		t.Errorf("names %v, descriptions %v; want en and om names and no descriptions", names, blocks[0].Descriptions)
+	}
+	if err := os.WriteFile(path, []byte(`[{"topic_key": "headache", "name_om": 7}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := content.LoadBlockFile(path); err == nil {
+		t.Error("LoadBlockFile accepted a name that is not a string")
+	}
+}
+
+// TestMapTopicHybrid verifies that confident local matches skip the LLM, ambiguous input goes to
+// it, and a failed LLM call falls back to the best local candidate
+func TestMapTopicHybrid(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+	mapper := newLocalTopicMapper(t, contentService)
+	// Any prompt that reaches the LLM unexpectedly is answered with indigestion
+	client := llm.NewScriptedClient([]llm.ScriptedResponse{
+		{Match: "and a fever", Error: "connection refused"},
+		{Match: "knee", Response: `{"topic_key": "back_pain"}`},
+	}, `{"topic_key": "indigestion"}`)
+	mapService := remedymate_services.NewMapTopicService(client, loadPrompts(t))
+	hybrid := usecase.NewRemedyMateUsecase(nil, contentService, nil, mapService, mapper, nil)
+
+	cases := map[string]string{
+		"my head is pounding":           "headache",  // confident, no LLM call
+		"I have a headache and a fever": "headache",  // ambiguous, LLM down
+		"my knee hurts":                 "back_pain", // no local match, LLM decides
+	}
+	for input, want := range cases {
+		if key, err := hybrid.MapTopic(context.Background(), input); err != nil || key != want {
+			t.Errorf("MapTopic(%q) = %q, %v; want %s", input, key, err, want)
+		}
+	}
+
+	// Local only: no LLM at all
+	local := usecase.NewRemedyMateUsecase(nil, contentService, nil, nil, mapper, nil)
+	if key, err := local.MapTopic(context.Background(), "ሳል አለብኝ"); err != nil || key != "cough" {
+		t.Errorf("local MapTopic = %q, %v; want cough", key, err)
+	}
+	if _, err := local.MapTopic(context.Background(), "my broken phone"); !errors.Is(err, derrors.ErrNoTopicMapped) {
+		t.Errorf("local MapTopic error = %v, want ErrNoTopicMapped", err)
+	}
+}
@@ -0,0 +1,204 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/bootstrap"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/usecase"
+)
+
+// memoryTopicRevisionRepo is an in-memory TopicRevisionRepository
+type memoryTopicRevisionRepo struct {
+	mu        sync.Mutex
+	revisions []entities.TopicRevision
+}
+
+func newMemoryTopicRevisionRepo() *memoryTopicRevisionRepo {
+	return &memoryTopicRevisionRepo{}
+}
+
+func (r *memoryTopicRevisionRepo) Create(ctx context.Context, revision *entities.TopicRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.revisions {
+		if existing.TopicKey == revision.TopicKey && existing.Version == revision.Version {
+			return derrors.ErrTopicRevisionExists
+		}
+	}
+	r.revisions = append(r.revisions, *revision)
+	return nil
+}
+
+func (r *memoryTopicRevisionRepo) List(ctx context.Context, topicKey string) ([]entities.TopicRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]entities.TopicRevision, 0)
+	for _, revision := range r.revisions {
+		if revision.TopicKey == topicKey {
+			out = append(out, revision)
+		}
+	}
+	return out, nil
+}
+
+func (r *memoryTopicRevisionRepo) Get(ctx context.Context, topicKey string, version int) (*entities.TopicRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, revision := range r.revisions {
+		if revision.TopicKey == topicKey && revision.Version == version {
+			return &revision, nil
+		}
+	}
+	return nil, derrors.ErrTopicRevisionNotFound
+}
+
+func (r *memoryTopicRevisionRepo) LatestAt(ctx context.Context, topicKey string, at time.Time) (*entities.TopicRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *entities.TopicRevision
+	for i, revision := range r.revisions {
+		if revision.TopicKey == topicKey && !revision.PublishedAt.After(at) && (latest == nil || revision.Version > latest.Version) {
+			latest = &r.revisions[i]
+		}
+	}
+	if latest == nil {
+		return nil, derrors.ErrTopicRevisionNotFound
+	}
+	found := *latest
+	return &found, nil
+}
+
+// TestTopicRevisions verifies that every publication is kept, versions can be compared and rolled
+// back, and the version served at a past time can be looked up
+func TestTopicRevisions(t *testing.T) {
+	repo, revisions := newMemoryTopicRepo(), newMemoryTopicRevisionRepo()
+	beforeSeed := time.Now()
+	if err := bootstrap.SeedTopics(repo, revisions, "../data"); err != nil {
+		t.Fatalf("SeedTopics returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", nil, repo)
+	topics := usecase.NewTopicUsecase(repo, revisions, contentService.(*content.ContentService), dto.TopicPublishConfig{})
+	author, reviewer := adminContext(), adminContext()
+
+	seeded := repoTopic(t, repo, "fever")
+	original := seeded.Translations["en"]
+	edited := original
+	edited.SelfCare = append([]string{"Rest in a cool room"}, original.SelfCare[1:]...)
+	update := dto.TopicUpdateRequest{Translations: map[string]entities.LocalizedGuidanceContent{"en": edited, "am": seeded.Translations["am"]}}
+	if _, err := topics.UpdateTopic(author, "fever", update); err != nil {
+		t.Fatalf("UpdateTopic returned error: %v", err)
+	}
+
+	// The pending draft can be compared with what is served
+	diff, err := topics.DiffRevisions(reviewer, "fever", "1", "draft")
+	if err != nil {
+		t.Fatalf("DiffRevisions returned error: %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Field != "translations.en.self_care" ||
+		len(diff.Changes[0].Added) != 1 || diff.Changes[0].Added[0] != "Rest in a cool room" ||
+		len(diff.Changes[0].Removed) != 1 || diff.Changes[0].Removed[0] != original.SelfCare[0] {
+		t.Fatalf("unexpected diff: %+v", diff.Changes)
+	}
+
+	servedV1 := time.Now()
+	transition(t, author, topics, "fever", entities.TopicStateInReview, "")
+	transition(t, reviewer, topics, "fever", entities.TopicStateApproved, "")
+	transition(t, reviewer, topics, "fever", entities.TopicStatePublished, "cooler room advice")
+	servedV2 := time.Now()
+
+	history, err := topics.ListRevisions(reviewer, "fever")
+	if err != nil || len(history) != 2 || history[1].Version != 2 || history[1].Notes != "cooler room advice" {
+		t.Fatalf("ListRevisions = %+v, %v; want versions 1 and 2", history, err)
+	}
+
+	// Rolling back submits the old content for review; it is served once someone else publishes it
+	topic, err := topics.RollbackTopic(author, "fever", 1, "advice under review again")
+	if err != nil {
+		t.Fatalf("RollbackTopic returned error: %v", err)
+	}
+	if topic.State != entities.TopicStateInReview || topic.Version != 2 || topic.Draft == nil || topic.Draft.RestoredFrom != 1 {
+		t.Fatalf("rollback: state %s, version %d, draft %+v", topic.State, topic.Version, topic.Draft)
+	}
+	if got, _ := contentService.GetContentByTopic("fever", "en"); got.SelfCare[0] != "Rest in a cool room" {
+		t.Errorf("rollback served before review: %v", got.SelfCare)
+	}
+	if _, err := topics.RollbackTopic(reviewer, "fever", 1, ""); !errors.Is(err, derrors.ErrInvalidTopicTransition) {
+		t.Errorf("rolling back over a pending draft: %v, want ErrInvalidTopicTransition", err)
+	}
+	if _, err := topics.TransitionTopic(author, "fever", dto.TopicTransitionRequest{To: entities.TopicStateApproved}); !errors.Is(err, derrors.ErrSelfReview) {
+		t.Errorf("approving own rollback: %v, want ErrSelfReview", err)
+	}
+	transition(t, reviewer, topics, "fever", entities.TopicStateApproved, "")
+	transition(t, reviewer, topics, "fever", entities.TopicStatePublished, "")
+	if got, _ := contentService.GetContentByTopic("fever", "en"); got.SelfCare[0] != original.SelfCare[0] {
+		t.Errorf("rolled back content not served: %v", got.SelfCare)
+	}
+	if restored, err := topics.GetRevision(reviewer, "fever", 3); err != nil || restored.RestoredFrom != 1 {
+		t.Errorf("GetRevision(3) = %+v, %v; want restored from 1", restored, err)
+	}
+	if diff, _ := topics.DiffRevisions(reviewer, "fever", "1", "3"); len(diff.Changes) != 0 {
+		t.Errorf("version 3 differs from version 1: %+v", diff.Changes)
+	}
+	if _, err := topics.RollbackTopic(reviewer, "fever", 3, ""); !errors.Is(err, derrors.ErrInvalidInput) {
+		t.Errorf("rolling back to the published version: %v, want ErrInvalidInput", err)
+	}
+
+	// What users were told about fever at each point in time
+	cases := []struct {
+		at      time.Time
+		served  bool
+		version int
+	}{
+		{beforeSeed, false, 0},
+		{servedV1, true, 1},
+		{servedV2, true, 2},
+		{time.Now(), true, 3},
+	}
+	for _, c := range cases {
+		served, err := topics.ServedAt(reviewer, "fever", c.at)
+		if err != nil {
+			t.Fatalf("ServedAt returned error: %v", err)
+		}
+		if served.Served != c.served || (c.served && served.Revision.Version != c.version) {
+			t.Errorf("ServedAt(%s) = served %v revision %+v, want version %d", c.at, served.Served, served.Revision, c.version)
+		}
+	}
+	transition(t, reviewer, topics, "fever", entities.TopicStateArchived, "")
+	if served, _ := topics.ServedAt(reviewer, "fever", time.Now()); served.Served {
+		t.Error("archived topic reported as served")
+	}
+
+	// A deleted topic's history can still be looked up, up to the time it was deleted
+	servedHeadache := time.Now()
+	if err := topics.SoftDeleteTopic(reviewer, "headache"); err != nil {
+		t.Fatalf("SoftDeleteTopic returned error: %v", err)
+	}
+	if served, err := topics.ServedAt(reviewer, "headache", servedHeadache); err != nil || !served.Served || served.Revision.Version != 1 {
+		t.Errorf("ServedAt before deletion = %+v, %v; want version 1", served, err)
+	}
+	if served, err := topics.ServedAt(reviewer, "headache", time.Now()); err != nil || served.Served {
+		t.Errorf("ServedAt after deletion = %+v, %v; want not served", served, err)
+	}
+	if history, err := topics.ListRevisions(reviewer, "headache"); err != nil || len(history) != 1 {
+		t.Errorf("ListRevisions of deleted topic = %+v, %v", history, err)
+	}
+	// Deletions from before they were recorded as transitions use the time of the deletion
+	servedCough := time.Now()
+	if err := repo.DeleteTopic(reviewer, "cough", ""); err != nil {
+		t.Fatalf("DeleteTopic returned error: %v", err)
+	}
+	if served, err := topics.ServedAt(reviewer, "cough", servedCough); err != nil || !served.Served {
+		t.Errorf("ServedAt before deletion = %+v, %v; want served", served, err)
+	}
+	if served, err := topics.ServedAt(reviewer, "cough", time.Now()); err != nil || served.Served {
+		t.Errorf("ServedAt after deletion = %+v, %v; want not served", served, err)
+	}
+}
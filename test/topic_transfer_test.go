@@ -0,0 +1,95 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/bootstrap"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/usecase"
+	"remedymate-backend/util/topicfile"
+)
+
+// TestTopicImportExport verifies that a CSV export survives a spreadsheet round trip, that
+// imports go to drafts merged per language, and that invalid rows stop the whole import
+func TestTopicImportExport(t *testing.T) {
+	repo, revisions := newMemoryTopicRepo(), newMemoryTopicRevisionRepo()
+	if err := bootstrap.SeedTopics(repo, revisions, "../data"); err != nil {
+		t.Fatalf("SeedTopics returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", nil, repo)
+	topics := usecase.NewTopicUsecase(repo, revisions, contentService.(*content.ContentService), dto.TopicPublishConfig{})
+	translator := adminContext()
+
+	records, err := topics.ExportTopics(translator, dto.TopicExportQuery{TopicKeys: []string{"fever", "cough"}, Languages: []string{"am"}})
+	if err != nil || len(records) != 2 || records[0].TopicKey != "cough" {
+		t.Fatalf("ExportTopics = %d records, %v", len(records), err)
+	}
+	if _, ok := records[1].Translations["en"]; ok {
+		t.Error("export filtered to am contains en")
+	}
+	var file bytes.Buffer
+	if err := topicfile.Write(&file, topicfile.FormatCSV, records); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if header := strings.SplitN(file.String(), "\n", 2)[0]; !strings.Contains(header, "translations.am.self_care") || strings.Contains(header, ".en.") {
+		t.Errorf("unexpected CSV header %q", header)
+	}
+
+	// The translator rewrites the first Amharic self-care line of fever
+	fever := repoTopic(t, repo, "fever")
+	oldLine := fever.Translations["am"].SelfCare[0]
+	edited := strings.Replace(file.String(), `,"`+oldLine+"\n", `,"ብዙ ፈሳሽ ይጠጡ`+"\n", 1)
+	imported, err := topicfile.Read(strings.NewReader(edited), topicfile.FormatCSV)
+	if err != nil || len(imported) != 2 {
+		t.Fatalf("Read = %d records, %v", len(imported), err)
+	}
+
+	report, err := topics.ImportTopics(translator, imported, true)
+	if err != nil {
+		t.Fatalf("ImportTopics returned error: %v", err)
+	}
+	if report.Applied || report.Updated != 1 || report.Unchanged != 1 || report.Rows[1].Version != fever.Version {
+		t.Fatalf("dry run report = %+v", report)
+	}
+	if repoTopic(t, repo, "fever").Draft != nil {
+		t.Fatal("dry run wrote a draft")
+	}
+
+	if report, err = topics.ImportTopics(translator, imported, false); err != nil || !report.Applied {
+		t.Fatalf("ImportTopics = %+v, %v", report, err)
+	}
+	draft := repoTopic(t, repo, "fever").Draft
+	if draft == nil || draft.Translations["am"].SelfCare[0] != "ብዙ ፈሳሽ ይጠጡ" {
+		t.Fatalf("import did not reach the draft: %+v", draft)
+	}
+	if len(draft.Translations["en"].SelfCare) == 0 || draft.Translations["am"].Disclaimer == "" {
+		t.Error("import dropped content that was not in the file")
+	}
+	if got, _ := contentService.GetContentByTopic("fever", "am"); got.SelfCare[0] != oldLine {
+		t.Error("imported change served before review")
+	}
+
+	// One bad row keeps every row out
+	invalid := []dto.TopicRecord{
+		{TopicKey: "hiccups", TopicContent: entities.TopicContent{NameEN: "Hiccups", NameAM: "ሳግ"}},
+		{TopicKey: "Bad Key"},
+		{TopicKey: "cough", Version: 7},
+		{TopicKey: "new_topic", TopicContent: entities.TopicContent{NameEN: "New"}},
+		{TopicKey: "headache", TopicContent: entities.TopicContent{Synonyms: map[string][]string{"xx": {"ouch"}}}},
+		{TopicKey: "hiccups"},
+	}
+	report, err = topics.ImportTopics(translator, invalid, false)
+	if err != nil {
+		t.Fatalf("ImportTopics returned error: %v", err)
+	}
+	if report.Applied || report.Invalid != 5 || report.Created != 1 || report.Rows[0].Action != dto.TopicImportCreated {
+		t.Errorf("invalid import report = %+v", report)
+	}
+	if exists, _ := repo.CheckTopicExists(translator, "hiccups"); exists {
+		t.Error("valid row written although other rows were invalid")
+	}
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/infrastructure/bootstrap"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/usecase"
+)
+
+// TestTranslationReport verifies that the report finds incomplete translations and Latin text in
+// Amharic fields, and that publishing is refused while a required language is incomplete
+func TestTranslationReport(t *testing.T) {
+	repo, revisions := newMemoryTopicRepo(), newMemoryTopicRevisionRepo()
+	if err := bootstrap.SeedTopics(repo, revisions, "../data"); err != nil {
+		t.Fatalf("SeedTopics returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", nil, repo)
+	topics := usecase.NewTopicUsecase(repo, revisions, contentService.(*content.ContentService),
+		dto.TopicPublishConfig{RequiredLanguages: []string{"en", "am"}})
+	author, reviewer := adminContext(), adminContext()
+
+	report, err := topics.TranslationReport(reviewer, nil)
+	if err != nil {
+		t.Fatalf("TranslationReport returned error: %v", err)
+	}
+	if report.TopicsChecked == 0 || report.TopicsComplete == report.TopicsChecked {
+		t.Errorf("report checked %d topics, %d complete", report.TopicsChecked, report.TopicsComplete)
+	}
+	issues := make(map[string]dto.TranslationIssue)
+	for _, status := range report.Topics {
+		for _, issue := range status.Issues {
+			issues[status.TopicKey+" "+issue.Field+" "+issue.Problem] = issue
+		}
+	}
+	if _, ok := issues["blisters_foot translations.am.seek_care_if empty_seek_care_if"]; !ok {
+		t.Error("empty Amharic seek_care_if not reported")
+	}
+	if issue, ok := issues["constipation translations.am.otc_categories wrong_script"]; !ok || issue.Detail != "not Ethiopic script: laxatives" {
+		t.Errorf("Latin text in Amharic not reported: %+v", issue)
+	}
+	if _, err := topics.TranslationReport(reviewer, []string{"xx"}); !errors.Is(err, derrors.ErrInvalidInput) {
+		t.Errorf("report for unknown language: %v, want ErrInvalidInput", err)
+	}
+
+	// The draft keeps the empty Amharic seek-care list, so it cannot be published
+	update := dto.TopicUpdateRequest{DescriptionEN: "Fluid-filled bubbles on the feet.", DescriptionAM: "Blisters በእግር ላይ"}
+	if _, err := topics.UpdateTopic(author, "blisters_foot", update); err != nil {
+		t.Fatalf("UpdateTopic returned error: %v", err)
+	}
+	if report, err := topics.TranslationReport(reviewer, []string{"am"}); err != nil {
+		t.Fatalf("TranslationReport returned error: %v", err)
+	} else if !hasIssue(report, "blisters_foot", "description_am", dto.TranslationWrongScript) {
+		t.Error("Latin text in the Amharic description not reported")
+	}
+	transition(t, author, topics, "blisters_foot", entities.TopicStateInReview, "")
+	transition(t, reviewer, topics, "blisters_foot", entities.TopicStateApproved, "")
+	if _, err := topics.TransitionTopic(reviewer, "blisters_foot", dto.TopicTransitionRequest{To: entities.TopicStatePublished}); !errors.Is(err, derrors.ErrIncompleteTranslation) {
+		t.Fatalf("publishing an incomplete translation: %v, want ErrIncompleteTranslation", err)
+	}
+
+	// Copied, since the in-memory revisions share the draft's map
+	translations := make(map[string]entities.LocalizedGuidanceContent)
+	for code, translation := range repoTopic(t, repo, "blisters_foot").Draft.Translations {
+		translations[code] = translation
+	}
+	am := translations["am"]
+	am.SeekCareIf = []string{"ቁስሉ ቀይ፣ ያበጠ ወይም መግል ካለው"}
+	translations["am"] = am
+	if _, err := topics.UpdateTopic(author, "blisters_foot", dto.TopicUpdateRequest{Translations: translations}); err != nil {
+		t.Fatalf("UpdateTopic returned error: %v", err)
+	}
+	transition(t, author, topics, "blisters_foot", entities.TopicStateInReview, "")
+	transition(t, reviewer, topics, "blisters_foot", entities.TopicStateApproved, "")
+	transition(t, reviewer, topics, "blisters_foot", entities.TopicStatePublished, "")
+
+	// The first version still has the empty list, so it cannot be rolled back to
+	if _, err := topics.RollbackTopic(author, "blisters_foot", 1, ""); !errors.Is(err, derrors.ErrIncompleteTranslation) {
+		t.Errorf("rolling back to an incomplete translation: %v, want ErrIncompleteTranslation", err)
+	}
+	if draft := repoTopic(t, repo, "blisters_foot").Draft; draft != nil {
+		t.Errorf("refused rollback left a draft: %+v", draft)
+	}
+}
+
+func hasIssue(report *dto.TranslationReport, topicKey, field, problem string) bool {
+	for _, status := range report.Topics {
+		for _, issue := range status.Issues {
+			if status.TopicKey == topicKey && issue.Field == field && issue.Problem == problem {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"remedymate-backend/delivery/controllers"
+	derrors "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/remedymate_services"
+	"remedymate-backend/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryTriageAuditRepo is an in-memory TriageAuditRepository with the repository's filter semantics
+type memoryTriageAuditRepo struct {
+	mu            sync.Mutex
+	audits        []entities.TriageAudit
+	snapshots     map[string]entities.TriageRuleSnapshot
+	snapshotSaves int
+}
+
+func newMemoryTriageAuditRepo() *memoryTriageAuditRepo {
+	return &memoryTriageAuditRepo{snapshots: make(map[string]entities.TriageRuleSnapshot)}
+}
+
+func (r *memoryTriageAuditRepo) Create(ctx context.Context, audit *entities.TriageAudit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	audit.ID = primitive.NewObjectID().Hex()
+	if audit.CreatedAt.IsZero() {
+		audit.CreatedAt = time.Now()
+	}
+	r.audits = append(r.audits, *audit)
+	return nil
+}
+
+func (r *memoryTriageAuditRepo) GetByID(ctx context.Context, id string) (*entities.TriageAudit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, audit := range r.audits {
+		if audit.ID == id {
+			return &audit, nil
+		}
+	}
+	return nil, derrors.ErrTriageAuditNotFound
+}
+
+func (r *memoryTriageAuditRepo) Search(ctx context.Context, filter dto.TriageAuditFilter) ([]entities.TriageAudit, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := make([]entities.TriageAudit, 0)
+	for _, audit := range r.audits {
+		if (filter.Level == "" || string(audit.Level) == filter.Level) &&
+			(filter.Language == "" || audit.Language == filter.Language) &&
+			(filter.From == nil || !audit.CreatedAt.Before(*filter.From)) &&
+			(filter.To == nil || audit.CreatedAt.Before(*filter.To)) {
+			matched = append(matched, audit)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	total := int64(len(matched))
+	if filter.Offset < len(matched) {
+		matched = matched[filter.Offset:]
+	} else {
+		matched = matched[:0]
+	}
+	if len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total, nil
+}
+
+func (r *memoryTriageAuditRepo) RuleStats(ctx context.Context, filter dto.TriageAuditFilter) ([]dto.TriageRuleStat, error) {
+	return []dto.TriageRuleStat{}, nil
+}
+
+func (r *memoryTriageAuditRepo) SaveRuleSnapshot(ctx context.Context, snapshot *entities.TriageRuleSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshotSaves++
+	if _, ok := r.snapshots[snapshot.Version]; !ok {
+		r.snapshots[snapshot.Version] = *snapshot
+	}
+	return nil
+}
+
+func (r *memoryTriageAuditRepo) GetRuleSnapshot(ctx context.Context, version string) (*entities.TriageRuleSnapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot, ok := r.snapshots[version]
+	if !ok {
+		return nil, derrors.ErrRuleSnapshotNotFound
+	}
+	return &snapshot, nil
+}
+
+// TestTriageAuditRecorded verifies that each triage decision is stored with what produced it, and
+// that the rule set is snapshotted once per rules version
+func TestTriageAuditRecorded(t *testing.T) {
+	ctx := context.Background()
+	rules := newMemoryRedFlagRepo()
+	chest := &entities.RedFlag{Keywords: []string{"crushing chest pain"}, Language: "en", Level: entities.TriageLevelRed, Description: "Chest pain"}
+	if err := rules.Create(ctx, chest); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", rules, nil)
+	ruleProvider := contentService.(interfaces.TriageRuleProvider)
+	reply := `{"level": "GREEN", "flags": []}`
+	llm := &MockLLMClient{}
+	llm.On("Generate", mock.Anything, mock.Anything).Return(reply, nil).After(5 * time.Millisecond)
+	triageService := remedymate_services.NewTriageService(contentService, llm, loadMessages(t), loadPrompts(t), dto.TriageConfig{})
+	audits := newMemoryTriageAuditRepo()
+	remedyMate := usecase.NewRemedyMateUsecase(triageService, contentService, nil, nil, nil, audits)
+
+	for _, text := range []string{"I have a mild headache", "I have crushing chest pain", "I have a mild headache"} {
+		if _, err := remedyMate.GetTriage(ctx, text, "en", nil); err != nil {
+			t.Fatalf("GetTriage(%q) returned error: %v", text, err)
+		}
+	}
+	if len(audits.audits) != 3 {
+		t.Fatalf("recorded %d audits, want 3", len(audits.audits))
+	}
+
+	version := ruleProvider.RulesVersion()
+	byLLM, byKeyword := audits.audits[0], audits.audits[1]
+	if byLLM.Source != entities.TriageSourceLLM || byLLM.Level != entities.TriageLevelGreen || byLLM.SessionID == "" {
+		t.Errorf("LLM audit = %+v", byLLM)
+	}
+	if byLLM.PromptHash == "" || byLLM.PromptVersion == "" || byLLM.RawResponse != reply || byLLM.LatencyMs < 5 || byLLM.RulesVersion != version {
+		t.Errorf("LLM audit trace: hash %q, prompt %q, raw %q, latency %dms, rules %q (want %q)",
+			byLLM.PromptHash, byLLM.PromptVersion, byLLM.RawResponse, byLLM.LatencyMs, byLLM.RulesVersion, version)
+	}
+	if byKeyword.Source != entities.TriageSourceKeyword || byKeyword.Level != entities.TriageLevelRed ||
+		len(byKeyword.MatchedRules) != 1 || byKeyword.MatchedRules[0].RuleID != chest.ID || byKeyword.RulesVersion != version {
+		t.Errorf("keyword audit = %+v", byKeyword)
+	}
+
+	// The rules are stored once for their version, and again once they change
+	if audits.snapshotSaves != 1 {
+		t.Errorf("saved the rule snapshot %d times, want once", audits.snapshotSaves)
+	}
+	if snapshot, err := audits.GetRuleSnapshot(ctx, version); err != nil || len(snapshot.RedRules) != 1 || snapshot.RedRules[0].ID != chest.ID {
+		t.Errorf("GetRuleSnapshot(%s) = %+v, %v", version, snapshot, err)
+	}
+	toes := dto.CreateRedFlagDTO{Keywords: []string{"purple toes"}, Language: "en", Level: "RED", Description: "Purple toes"}
+	if _, err := usecase.NewAdminRedFlagUsecase(rules, ruleProvider).Create(ctx, toes, "admin"); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := remedyMate.GetTriage(ctx, "I have a mild headache", "en", nil); err != nil {
+		t.Fatalf("GetTriage returned error: %v", err)
+	}
+	if audits.snapshotSaves != 2 || len(audits.snapshots) != 2 || audits.audits[3].RulesVersion == version {
+		t.Errorf("after a rule change: %d saves, %d snapshots, audit rules %s", audits.snapshotSaves, len(audits.snapshots), audits.audits[3].RulesVersion)
+	}
+}
+
+// TestTriageAuditSearch verifies the level, language and date filters of the audit search,
+// including the inclusive plain-date and exclusive timestamp "to" bounds
+func TestTriageAuditSearch(t *testing.T) {
+	audits := newMemoryTriageAuditRepo()
+	for _, audit := range []entities.TriageAudit{
+		{InputText: "a", Level: entities.TriageLevelRed, Language: "en", CreatedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{InputText: "b", Level: entities.TriageLevelGreen, Language: "en", CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{InputText: "c", Level: entities.TriageLevelYellow, Language: "am", CreatedAt: time.Date(2025, 3, 2, 23, 0, 0, 0, time.UTC)},
+		{InputText: "d", Level: entities.TriageLevelGreen, Language: "en", CreatedAt: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+	} {
+		audit := audit
+		audits.Create(context.Background(), &audit)
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/triage-audits", controllers.NewAdminTriageAuditController(usecase.NewAdminTriageAuditUsecase(audits, nil)).List)
+
+	cases := []struct {
+		query  string
+		status int
+		want   string
+	}{
+		{"", http.StatusOK, "dcba"},
+		{"?level=green", http.StatusOK, "db"},
+		{"?language=am", http.StatusOK, "c"},
+		{"?from=2025-03-02", http.StatusOK, "dcb"},
+		{"?to=2025-03-02", http.StatusOK, "cba"},
+		{"?to=2025-03-02T00:00:00Z", http.StatusOK, "a"},
+		{"?from=2025-03-02T00:00:00Z&to=2025-03-02", http.StatusOK, "cb"},
+		{"?level=GREEN&language=en&from=2025-03-02T12:00:00Z", http.StatusOK, "d"},
+		{"?limit=2&offset=1", http.StatusOK, "cb"},
+		{"?from=yesterday", http.StatusBadRequest, ""},
+		{"?to=03/02/2025", http.StatusBadRequest, ""},
+	}
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/triage-audits"+tc.query, nil))
+		if recorder.Code != tc.status {
+			t.Errorf("%s: status %d, want %d", tc.query, recorder.Code, tc.status)
+			continue
+		}
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body struct {
+			Items []entities.TriageAudit `json:"items"`
+			Total int64                  `json:"total"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: invalid response: %v", tc.query, err)
+		}
+		got := ""
+		for _, item := range body.Items {
+			got += item.InputText
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.query, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/util/triageeval"
+)
+
+// TestTriageEvalReport verifies the confusion matrix, per-rule recall and under-triage list
+func TestTriageEvalReport(t *testing.T) {
+	red, yellow, green := entities.TriageLevelRed, entities.TriageLevelYellow, entities.TriageLevelGreen
+	result := func(id string, expected, got entities.TriageLevel, rule, err string) triageeval.CaseResult {
+		return triageeval.CaseResult{Case: triageeval.EvalCase{ID: id, Expected: expected, Rule: rule}, Got: got, Error: err}
+	}
+	report := triageeval.BuildReport([]triageeval.CaseResult{
+		result("stroke-1", red, red, "stroke", ""),
+		result("stroke-2", red, yellow, "stroke", ""),
+		result("chest-1", red, red, "chest", ""),
+		result("chest-2", red, "", "chest", "timeout"),
+		result("bleed-1", red, red, "", ""),
+		result("fever-1", yellow, green, "", ""),
+		result("fever-2", yellow, red, "", ""),
+		result("cold-1", green, green, "", ""),
+		result("cold-2", green, yellow, "", ""),
+	})
+
+	if report.Total != 9 || report.Correct != 4 || report.Errors != 1 {
+		t.Errorf("total %d, correct %d, errors %d; want 9, 4, 1", report.Total, report.Correct, report.Errors)
+	}
+	confusion := []struct {
+		expected, predicted string
+		count               int
+	}{
+		{"RED", "RED", 3}, {"RED", "YELLOW", 1}, {"RED", "ERROR", 1}, {"RED", "GREEN", 0},
+		{"YELLOW", "GREEN", 1}, {"YELLOW", "RED", 1}, {"YELLOW", "YELLOW", 0},
+		{"GREEN", "GREEN", 1}, {"GREEN", "YELLOW", 1},
+	}
+	for _, c := range confusion {
+		if got := report.Confusion[c.expected][c.predicted]; got != c.count {
+			t.Errorf("confusion[%s][%s] = %d, want %d", c.expected, c.predicted, got, c.count)
+		}
+	}
+	if report.RedRecall != 0.6 {
+		t.Errorf("RED recall = %v, want 0.6", report.RedRecall)
+	}
+
+	// Worst rules first, then by name
+	wantRules := []triageeval.RuleRecall{
+		{Rule: "chest", Total: 2, Hits: 1, Recall: 0.5},
+		{Rule: "stroke", Total: 2, Hits: 1, Recall: 0.5},
+		{Rule: "(unlabeled)", Total: 1, Hits: 1, Recall: 1},
+	}
+	if len(report.RuleRecall) != len(wantRules) {
+		t.Fatalf("rule recall = %+v, want %+v", report.RuleRecall, wantRules)
+	}
+	for i, want := range wantRules {
+		if report.RuleRecall[i] != want {
+			t.Errorf("rule recall[%d] = %+v, want %+v", i, report.RuleRecall[i], want)
+		}
+	}
+
+	// An error counts as under-triage; over-triage does not
+	var under []string
+	for _, r := range report.UnderTriaged {
+		under = append(under, r.Case.ID)
+	}
+	if want := []string{"stroke-2", "chest-2", "fever-1"}; len(under) != len(want) || under[0] != want[0] || under[1] != want[1] || under[2] != want[2] {
+		t.Errorf("under-triaged = %v, want %v", under, want)
+	}
+}
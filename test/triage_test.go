@@ -0,0 +1,436 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+	"remedymate-backend/infrastructure/bootstrap"
+	"remedymate-backend/infrastructure/content"
+	"remedymate-backend/infrastructure/remedymate_services"
+	"remedymate-backend/usecase"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryRedFlagRepo is an in-memory RedFlagRepository
+type memoryRedFlagRepo struct {
+	mu    sync.Mutex
+	flags map[string]entities.RedFlag
+}
+
+func newMemoryRedFlagRepo() *memoryRedFlagRepo {
+	return &memoryRedFlagRepo{flags: make(map[string]entities.RedFlag)}
+}
+
+func (r *memoryRedFlagRepo) List(ctx context.Context) ([]entities.RedFlag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]entities.RedFlag, 0)
+	for _, rf := range r.flags {
+		if !rf.IsDeleted {
+			out = append(out, rf)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *memoryRedFlagRepo) GetByID(ctx context.Context, id string) (*entities.RedFlag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rf, ok := r.flags[id]
+	if !ok || rf.IsDeleted {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &rf, nil
+}
+
+func (r *memoryRedFlagRepo) Create(ctx context.Context, rf *entities.RedFlag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rf.ID = primitive.NewObjectID().Hex()
+	rf.CreatedAt = time.Now()
+	rf.UpdatedAt = rf.CreatedAt
+	r.flags[rf.ID] = *rf
+	return nil
+}
+
+func (r *memoryRedFlagRepo) Update(ctx context.Context, rf *entities.RedFlag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rf.UpdatedAt = time.Now()
+	r.flags[rf.ID] = *rf
+	return nil
+}
+
+func (r *memoryRedFlagRepo) SoftDelete(ctx context.Context, id string, deletedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rf := r.flags[id]
+	now := time.Now()
+	rf.IsDeleted, rf.DeletedAt, rf.DeletedBy = true, &now, &deletedBy
+	r.flags[id] = rf
+	return nil
+}
+
+// SeedRule follows the repository: a rule with the same seed ID is left alone, deleted or not,
+// and a rule stored without a seed ID is adopted when its level, language and description match
+func (r *memoryRedFlagRepo) SeedRule(ctx context.Context, rf *entities.RedFlag) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.flags {
+		if existing.SeedID == rf.SeedID {
+			return false, nil
+		}
+	}
+	for id, existing := range r.flags {
+		if existing.SeedID == "" && existing.Level == rf.Level && existing.Language == rf.Language && existing.Description == rf.Description {
+			existing.SeedID = rf.SeedID
+			r.flags[id] = existing
+			return false, nil
+		}
+	}
+	seeded := *rf
+	seeded.ID = primitive.NewObjectID().Hex()
+	seeded.CreatedAt = time.Now()
+	seeded.UpdatedAt = seeded.CreatedAt
+	r.flags[seeded.ID] = seeded
+	return true, nil
+}
+
+// TestTriageKeywordPrescreen verifies that red flag keywords short-circuit the LLM
+func TestTriageKeywordPrescreen(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+	mockLLM := &MockLLMClient{}
+	triageService := remedymate_services.NewTriageService(contentService, mockLLM, loadMessages(t), loadPrompts(t), dto.TriageConfig{})
+
+	cases := []struct {
+		text string
+		lang string
+	}{
+		{text: "I have crushing chest pain", lang: "en"},
+		{text: "My father has SHORTNESS OF  BREATH", lang: "en"},
+		{text: "የደረት ህመም አለብኝ", lang: "am"},
+	}
+
+	for _, tc := range cases {
+		result, err := triageService.ClassifySymptoms(context.Background(), tc.text, tc.lang, nil)
+		if err != nil {
+			t.Fatalf("ClassifySymptoms(%q) returned error: %v", tc.text, err)
+		}
+		if result.Level != entities.TriageLevelRed {
+			t.Errorf("ClassifySymptoms(%q) level = %s, want RED", tc.text, result.Level)
+		}
+		if len(result.RedFlags) == 0 {
+			t.Errorf("ClassifySymptoms(%q) returned no red flags", tc.text)
+		}
+	}
+
+	// The LLM must never be consulted when a red flag keyword matched
+	mockLLM.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything)
+}
+
+// TestTriageLLMCannotLowerKeywordLevel verifies that the LLM can raise but not lower the pre-screen level
+func TestTriageLLMCannotLowerKeywordLevel(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+
+	greenLLM := &MockLLMClient{}
+	greenLLM.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "GREEN", "flags": []}`, nil)
+	result, err := remedymate_services.NewTriageService(contentService, greenLLM, loadMessages(t), loadPrompts(t), dto.TriageConfig{}).
+		ClassifySymptoms(context.Background(), "I have a high fever since yesterday", "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error: %v", err)
+	}
+	if result.Level != entities.TriageLevelYellow {
+		t.Errorf("level = %s, want YELLOW", result.Level)
+	}
+
+	// A yellow keyword hit is still returned when the LLM is unreachable
+	downLLM := &MockLLMClient{}
+	downLLM.On("Generate", mock.Anything, mock.Anything).Return("", errors.New("connection refused"))
+	result, err = remedymate_services.NewTriageService(contentService, downLLM, loadMessages(t), loadPrompts(t), dto.TriageConfig{}).
+		ClassifySymptoms(context.Background(), "I have a high fever since yesterday", "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error with LLM down: %v", err)
+	}
+	if result.Level != entities.TriageLevelYellow {
+		t.Errorf("level with LLM down = %s, want YELLOW", result.Level)
+	}
+
+	// Without a keyword hit the LLM decides and may escalate
+	redLLM := &MockLLMClient{}
+	redLLM.On("Generate", mock.Anything, mock.Anything).Return("```json\n{\"level\": \"RED\", \"flags\": [\"stiff neck\"]}\n```", nil)
+	result, err = remedymate_services.NewTriageService(contentService, redLLM, loadMessages(t), loadPrompts(t), dto.TriageConfig{}).
+		ClassifySymptoms(context.Background(), "headache with a stiff neck", "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error: %v", err)
+	}
+	if result.Level != entities.TriageLevelRed {
+		t.Errorf("level = %s, want RED", result.Level)
+	}
+}
+
+// TestTriageConsensusEscalatesDisagreement verifies that split votes are raised to at least YELLOW
+func TestTriageConsensusEscalatesDisagreement(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+	config := dto.TriageConfig{ConsensusSamples: 3}
+
+	agreeLLM := &MockLLMClient{}
+	agreeLLM.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "GREEN", "flags": []}`, nil)
+	result, err := remedymate_services.NewTriageService(contentService, agreeLLM, loadMessages(t), loadPrompts(t), config).
+		ClassifySymptoms(context.Background(), "I have a mild headache", "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error: %v", err)
+	}
+	if result.Level != entities.TriageLevelGreen || result.Agreement != 1 || result.LLMCalls != 3 {
+		t.Errorf("unanimous GREEN: level=%s agreement=%.2f calls=%d", result.Level, result.Agreement, result.LLMCalls)
+	}
+
+	splitLLM := &MockLLMClient{}
+	splitLLM.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "GREEN", "flags": []}`, nil).Twice()
+	splitLLM.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "UNCLEAR", "flags": []}`, nil).Once()
+	result, err = remedymate_services.NewTriageService(contentService, splitLLM, loadMessages(t), loadPrompts(t), config).
+		ClassifySymptoms(context.Background(), "I have a mild headache", "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error: %v", err)
+	}
+	if result.Level != entities.TriageLevelYellow {
+		t.Errorf("split vote level = %s, want YELLOW", result.Level)
+	}
+	if result.Agreement >= 1 {
+		t.Errorf("split vote agreement = %.2f, want < 1", result.Agreement)
+	}
+	splitLLM.AssertNumberOfCalls(t, "Generate", 3)
+}
+
+// TestTriageMatchedRuleEvidence verifies that results point at the rule and the user's words that fired it
+func TestTriageMatchedRuleEvidence(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+
+	input := "My father has SHORTNESS OF  BREATH"
+	result, err := remedymate_services.NewTriageService(contentService, &MockLLMClient{}, loadMessages(t), loadPrompts(t), dto.TriageConfig{}).
+		ClassifySymptoms(context.Background(), input, "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error: %v", err)
+	}
+	if len(result.MatchedRules) != 1 {
+		t.Fatalf("matched rules = %+v, want exactly one", result.MatchedRules)
+	}
+	match := result.MatchedRules[0]
+	if match.RuleID == "" || match.Level != entities.TriageLevelRed || match.Source != entities.TriageSourceKeyword {
+		t.Errorf("unexpected keyword match %+v", match)
+	}
+	if got := string([]rune(input)[match.Start:match.End]); got != "SHORTNESS OF  BREATH" || match.Evidence != got {
+		t.Errorf("evidence span = %q (evidence %q), want the user's words", got, match.Evidence)
+	}
+
+	// LLM citations are kept only for known rules, and located evidence raises the level
+	var strokeRule entities.RedFlagRule
+	for _, rule := range contentService.(interfaces.TriageRuleProvider).RuleSnapshot().RedRules {
+		if rule.Language == "en" && strings.HasPrefix(rule.Description, "Stroke") {
+			strokeRule = rule
+		}
+	}
+	llm := &MockLLMClient{}
+	llm.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "YELLOW", "flags": ["arm weakness"],
+		"matches": [{"rule_id": "`+strokeRule.ID+`", "evidence": "can't lift my right arm"}, {"rule_id": "made-up", "evidence": "arm"}]}`, nil)
+	result, err = remedymate_services.NewTriageService(contentService, llm, loadMessages(t), loadPrompts(t), dto.TriageConfig{}).
+		ClassifySymptoms(context.Background(), "Suddenly I can’t lift my right arm", "en", nil)
+	if err != nil {
+		t.Fatalf("ClassifySymptoms returned error: %v", err)
+	}
+	if result.Level != entities.TriageLevelRed {
+		t.Errorf("level = %s, want RED from the cited stroke rule", result.Level)
+	}
+	if len(result.MatchedRules) != 1 || result.MatchedRules[0].RuleID != strokeRule.ID {
+		t.Fatalf("matched rules = %+v, want only the stroke rule", result.MatchedRules)
+	}
+	if got := result.MatchedRules[0]; got.Evidence != "can’t lift my right arm" || got.Start != 11 || got.Source != entities.TriageSourceLLM {
+		t.Errorf("unexpected LLM match %+v", got)
+	}
+}
+
+// TestTriagePatientConditions verifies that conditional rules fire only for patients who meet them
+func TestTriagePatientConditions(t *testing.T) {
+	contentService := content.NewContentService("../data", nil, nil)
+	llm := &MockLLMClient{}
+	llm.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "GREEN", "flags": []}`, nil)
+	triageService := remedymate_services.NewTriageService(contentService, llm, loadMessages(t), loadPrompts(t), dto.TriageConfig{})
+
+	cases := []struct {
+		name    string
+		text    string
+		patient *entities.PatientContext
+		want    entities.TriageLevel
+	}{
+		{"fever without context", "My son has had a fever since last night", nil, entities.TriageLevelGreen},
+		{"fever in an infant", "My son has had a fever since last night", &entities.PatientContext{AgeBand: entities.AgeBandInfant}, entities.TriageLevelRed},
+		{"fever in an adult", "My son has had a fever since last night", &entities.PatientContext{AgeBand: entities.AgeBandAdult}, entities.TriageLevelGreen},
+		{"headache in pregnancy", "I have a headache", &entities.PatientContext{Pregnancy: entities.PregnancyStatusPregnant}, entities.TriageLevelRed},
+		{"headache not pregnant", "I have a headache", &entities.PatientContext{Pregnancy: entities.PregnancyStatusNotPregnant}, entities.TriageLevelGreen},
+		{"vomiting with diabetes", "I started vomiting", &entities.PatientContext{ChronicConditions: []string{"Diabetes"}}, entities.TriageLevelYellow},
+	}
+	for _, tc := range cases {
+		result, err := triageService.ClassifySymptoms(context.Background(), tc.text, "en", tc.patient)
+		if err != nil {
+			t.Fatalf("%s: ClassifySymptoms returned error: %v", tc.name, err)
+		}
+		if result.Level != tc.want {
+			t.Errorf("%s: level = %s, want %s", tc.name, result.Level, tc.want)
+		}
+	}
+}
+
+// TestTriageRulesRefresh verifies that admin edits and deletions of rules reach triage without a
+// restart, including deleting every rule
+func TestTriageRulesRefresh(t *testing.T) {
+	repo := newMemoryRedFlagRepo()
+	chest := &entities.RedFlag{Keywords: []string{"crushing chest pain"}, Language: "en", Level: entities.TriageLevelRed, Description: "Chest pain"}
+	if err := repo.Create(context.Background(), chest); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	contentService := content.NewContentService("../data", repo, nil)
+	rules := contentService.(interfaces.TriageRuleProvider)
+	admin := usecase.NewAdminRedFlagUsecase(repo, rules)
+	llm := &MockLLMClient{}
+	llm.On("Generate", mock.Anything, mock.Anything).Return(`{"level": "GREEN", "flags": []}`, nil)
+	triageService := remedymate_services.NewTriageService(contentService, llm, loadMessages(t), loadPrompts(t), dto.TriageConfig{})
+
+	level := func(text string) entities.TriageLevel {
+		t.Helper()
+		result, err := triageService.ClassifySymptoms(context.Background(), text, "en", nil)
+		if err != nil {
+			t.Fatalf("ClassifySymptoms(%q) returned error: %v", text, err)
+		}
+		return result.Level
+	}
+
+	// The database rules replace the bundled ones
+	if got := level("my breathing stopped, I have shortness of breath"); got != entities.TriageLevelGreen {
+		t.Errorf("bundled rule still fires: %s", got)
+	}
+	if got := level("I have crushing chest pain"); got != entities.TriageLevelRed {
+		t.Errorf("database rule does not fire: %s", got)
+	}
+
+	ctx := context.Background()
+	created, err := admin.Create(ctx, dto.CreateRedFlagDTO{Keywords: []string{"purple toes"}, Language: "en", Level: "RED", Description: "Purple toes"}, "admin")
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if got := level("I have purple toes"); got != entities.TriageLevelRed {
+		t.Errorf("created rule: %s, want RED", got)
+	}
+	if _, err := admin.Update(ctx, created.ID, dto.UpdateRedFlagDTO{Keywords: []string{"blue toes"}}, "admin"); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if got := level("I have purple toes"); got != entities.TriageLevelGreen {
+		t.Errorf("edited-out keyword: %s, want GREEN", got)
+	}
+	if got := level("I have blue toes"); got != entities.TriageLevelRed {
+		t.Errorf("edited keyword: %s, want RED", got)
+	}
+	if err := admin.Delete(ctx, created.ID, "admin"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if got := level("I have blue toes"); got != entities.TriageLevelGreen {
+		t.Errorf("deleted rule: %s, want GREEN", got)
+	}
+
+	// Deleting the last rule leaves none, rather than keeping the deleted ones
+	if err := admin.Delete(ctx, chest.ID, "admin"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if got := level("I have crushing chest pain"); got != entities.TriageLevelGreen {
+		t.Errorf("rule deleted last still fires: %s", got)
+	}
+	if snapshot := rules.RuleSnapshot(); len(snapshot.RedRules)+len(snapshot.YellowRules) != 0 {
+		t.Errorf("rules left after deleting all: %+v", snapshot)
+	}
+}
+
+// TestSeedRedFlags verifies that bundled rules are seeded once by their file ID: re-seeding,
+// editing a bundled description and deleting a seeded rule never add a rule again, and rules
+// seeded before IDs were recorded are adopted rather than duplicated
+func TestSeedRedFlags(t *testing.T) {
+	ctx := context.Background()
+	bundled := 0
+	for _, file := range []string{"red_flag_rules.json", "yellow_flag_rules.json"} {
+		rules, err := content.LoadRuleFile(filepath.Join("../data", file))
+		if err != nil {
+			t.Fatalf("LoadRuleFile(%s) returned error: %v", file, err)
+		}
+		bundled += len(rules)
+	}
+
+	// A rule stored by an older release without a seed ID, then given extra keywords by an admin
+	repo := newMemoryRedFlagRepo()
+	legacy := &entities.RedFlag{Keywords: []string{"chest pain", "tight chest"}, Language: "en", Level: entities.TriageLevelRed, Description: "Chest pain or pressure - potential heart attack"}
+	if err := repo.Create(ctx, legacy); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if err := bootstrap.SeedRedFlags(repo, "../data"); err != nil {
+		t.Fatalf("SeedRedFlags returned error: %v", err)
+	}
+	if len(repo.flags) != bundled {
+		t.Fatalf("seeded %d rules, want %d", len(repo.flags), bundled)
+	}
+	if adopted := repo.flags[legacy.ID]; adopted.SeedID != "red-chest-pain-en" || len(adopted.Keywords) != 2 {
+		t.Errorf("legacy rule = %+v, want it adopted with its edited keywords", adopted)
+	}
+	if err := bootstrap.SeedRedFlags(repo, "../data"); err != nil {
+		t.Fatalf("SeedRedFlags returned error: %v", err)
+	}
+	if len(repo.flags) != bundled {
+		t.Errorf("re-seeding left %d rules, want %d", len(repo.flags), bundled)
+	}
+
+	// A release that rewords a bundled rule keeps its ID, and a deleted rule stays deleted
+	data := t.TempDir()
+	for _, file := range []string{"red_flag_rules.json", "yellow_flag_rules.json"} {
+		raw, err := os.ReadFile(filepath.Join("../data", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw = []byte(strings.Replace(string(raw), "Severe breathing difficulties", "Severe difficulty breathing", 1))
+		if err := os.WriteFile(filepath.Join(data, file), raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var stroke string
+	for id, rf := range repo.flags {
+		if rf.SeedID == "red-stroke-en" {
+			stroke = id
+		}
+	}
+	if err := repo.SoftDelete(ctx, stroke, "admin"); err != nil {
+		t.Fatalf("SoftDelete returned error: %v", err)
+	}
+	if err := bootstrap.SeedRedFlags(repo, data); err != nil {
+		t.Fatalf("SeedRedFlags returned error: %v", err)
+	}
+	if len(repo.flags) != bundled || !repo.flags[stroke].IsDeleted {
+		t.Errorf("after a reworded release: %d rules (want %d), stroke deleted %v", len(repo.flags), bundled, repo.flags[stroke].IsDeleted)
+	}
+
+	// Every bundled rule needs an ID
+	missing := filepath.Join(t.TempDir(), "red_flag_rules.json")
+	if err := os.WriteFile(missing, []byte(`[{"keywords": ["chest pain"], "language": "en", "level": "RED", "description": "Chest pain"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := content.LoadRuleFile(missing); err == nil {
+		t.Error("LoadRuleFile accepted a rule without an id")
+	}
+}
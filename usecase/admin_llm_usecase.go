@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/interfaces"
+)
+
+// usageReportDays is the default window of the usage report
+const usageReportDays = 30
+
+type AdminLLMUsecaseImpl struct {
+	cache     interfaces.LLMCacheStatsProvider
+	usageRepo interfaces.LLMUsageRepository
+	budget    interfaces.LLMBudgetProvider
+	pricing   dto.LLMBudgetConfig
+	providers interfaces.LLMProviderStatusProvider
+}
+
+// NewAdminLLMUsecase exposes LLM operational data to admins. cache is nil when the response cache is off.
+func NewAdminLLMUsecase(cache interfaces.LLMCacheStatsProvider, usageRepo interfaces.LLMUsageRepository, budget interfaces.LLMBudgetProvider, pricing dto.LLMBudgetConfig, providers interfaces.LLMProviderStatusProvider) interfaces.AdminLLMUsecase {
+	return &AdminLLMUsecaseImpl{cache: cache, usageRepo: usageRepo, budget: budget, pricing: pricing, providers: providers}
+}
+
+func (uc *AdminLLMUsecaseImpl) CacheStats(ctx context.Context) (dto.LLMCacheStats, error) {
+	if uc.cache == nil {
+		return dto.LLMCacheStats{Backend: "off", Scopes: map[string]dto.LLMCacheScopeStats{}}, nil
+	}
+	return uc.cache.CacheStats(ctx)
+}
+
+// Usage returns daily token aggregates (the last 30 days unless a range is given) with
+// an estimated cost, and today's position against the daily budget
+func (uc *AdminLLMUsecaseImpl) Usage(ctx context.Context, filter dto.LLMUsageFilter) (*dto.LLMUsageReport, error) {
+	if filter.From == nil {
+		now := time.Now().UTC()
+		from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(usageReportDays - 1))
+		filter.From = &from
+	}
+
+	items, err := uc.usageRepo.DailyAggregates(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].EstimatedCost = float64(items[i].PromptTokens)/1000*uc.pricing.PromptPricePer1K +
+			float64(items[i].CompletionTokens)/1000*uc.pricing.CompletionPricePer1K
+	}
+
+	report := &dto.LLMUsageReport{Items: items}
+	if uc.budget != nil {
+		report.Budget = uc.budget.BudgetStatus()
+	}
+	return report, nil
+}
+
+// Providers returns the health of every configured provider in fallback order
+func (uc *AdminLLMUsecaseImpl) Providers(ctx context.Context) ([]dto.LLMProviderStatus, error) {
+	if uc.providers == nil {
+		return []dto.LLMProviderStatus{}, nil
+	}
+	return uc.providers.ProviderStatus(), nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	AppError "remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+)
+
+type AdminPromptUsecaseImpl struct {
+	repo     interfaces.PromptTemplateRepository
+	registry interfaces.PromptRegistry
+}
+
+// NewAdminPromptUsecase creates the admin prompt usecase. Activated versions are
+// pushed to the registry so they are used from the next LLM call on.
+func NewAdminPromptUsecase(repo interfaces.PromptTemplateRepository, registry interfaces.PromptRegistry) interfaces.AdminPromptUsecase {
+	return &AdminPromptUsecaseImpl{repo: repo, registry: registry}
+}
+
+// List summarizes every prompt with the version in use
+func (uc *AdminPromptUsecaseImpl) List(ctx context.Context) ([]dto.PromptTemplateSummary, error) {
+	stored, err := uc.repo.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]dto.PromptTemplateSummary, 0, len(uc.registry.Names()))
+	for _, name := range uc.registry.Names() {
+		summary := dto.PromptTemplateSummary{
+			Name:          name,
+			Fields:        uc.registry.Fields(name),
+			ActiveVersion: uc.registry.ActiveVersion(name),
+		}
+		for _, t := range stored {
+			if t.Name != name {
+				continue
+			}
+			summary.Versions++
+			if t.Version > summary.LatestVersion {
+				summary.LatestVersion = t.Version
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (uc *AdminPromptUsecaseImpl) Versions(ctx context.Context, name string) ([]entities.PromptTemplate, error) {
+	if !uc.known(name) {
+		return nil, AppError.ErrPromptTemplateNotFound
+	}
+	return uc.repo.List(ctx, name)
+}
+
+func (uc *AdminPromptUsecaseImpl) Get(ctx context.Context, name string, version int) (*entities.PromptTemplate, error) {
+	return uc.repo.Get(ctx, name, version)
+}
+
+// Create stores the body as the next version of the prompt, numbered from
+// AdminPromptVersionBase so it cannot clash with a file shipped later. It only goes live when activated.
+func (uc *AdminPromptUsecaseImpl) Create(ctx context.Context, name string, in dto.CreatePromptVersionDTO, actor string) (*entities.PromptTemplate, error) {
+	if err := uc.registry.Validate(name, in.Body); err != nil {
+		return nil, err
+	}
+	versions, err := uc.repo.List(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	next := entities.AdminPromptVersionBase
+	if len(versions) > 0 && versions[0].Version >= next {
+		next = versions[0].Version + 1
+	}
+
+	t := &entities.PromptTemplate{
+		Name:        name,
+		Version:     next,
+		Body:        in.Body,
+		Description: in.Description,
+		Source:      entities.PromptSourceAdmin,
+		CreatedBy:   actor,
+	}
+	if err := uc.repo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	if in.Activate {
+		return uc.Activate(ctx, name, next, actor)
+	}
+	return t, nil
+}
+
+// Activate makes a version live, or rolls back to an earlier one
+func (uc *AdminPromptUsecaseImpl) Activate(ctx context.Context, name string, version int, actor string) (*entities.PromptTemplate, error) {
+	t, err := uc.repo.Get(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	// The fields a prompt receives can change between releases, so check old versions again
+	if err := uc.registry.Validate(name, t.Body); err != nil {
+		return nil, fmt.Errorf("%w (version %d)", err, version)
+	}
+	if err := uc.repo.Activate(ctx, name, version, actor); err != nil {
+		return nil, err
+	}
+	if err := uc.registry.Refresh(ctx); err != nil {
+		log.Printf("❌ Failed to reload prompt templates after activating %s: %v", entities.PromptVersionRef(name, version), err)
+	}
+	log.Printf("✅ Prompt %s activated by %s", entities.PromptVersionRef(name, version), actor)
+	return uc.repo.Get(ctx, name, version)
+}
+
+func (uc *AdminPromptUsecaseImpl) known(name string) bool {
+	for _, n := range uc.registry.Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
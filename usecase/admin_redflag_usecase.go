@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log"
 	"strings"
 
 	"remedymate-backend/domain/dto"
@@ -10,11 +11,14 @@ import (
 )
 
 type AdminRedFlagUsecaseImpl struct {
-	repo interfaces.RedFlagRepository
+	repo  interfaces.RedFlagRepository
+	rules interfaces.TriageRuleProvider
 }
 
-func NewAdminRedFlagUsecase(repo interfaces.RedFlagRepository) interfaces.AdminRedFlagUsecase {
-	return &AdminRedFlagUsecaseImpl{repo: repo}
+// NewAdminRedFlagUsecase creates the admin red flag usecase. Edits are pushed to
+// the triage rule provider so they take effect without a restart.
+func NewAdminRedFlagUsecase(repo interfaces.RedFlagRepository, rules interfaces.TriageRuleProvider) interfaces.AdminRedFlagUsecase {
+	return &AdminRedFlagUsecaseImpl{repo: repo, rules: rules}
 }
 
 func (uc *AdminRedFlagUsecaseImpl) List(ctx context.Context) ([]entities.RedFlag, error) {
@@ -37,11 +41,13 @@ func (uc *AdminRedFlagUsecaseImpl) Create(ctx context.Context, in dto.CreateRedF
 		Language:    in.Language,
 		Level:       level,
 		Description: in.Description,
+		Conditions:  in.Conditions.ToEntity(),
 		CreatedBy:   &actor,
 	}
 	if err := uc.repo.Create(ctx, rf); err != nil {
 		return nil, err
 	}
+	uc.refreshTriageRules(ctx)
 	return rf, nil
 }
 
@@ -62,10 +68,14 @@ func (uc *AdminRedFlagUsecaseImpl) Update(ctx context.Context, id string, in dto
 	if in.Description != "" {
 		existing.Description = in.Description
 	}
+	if in.Conditions != nil {
+		existing.Conditions = in.Conditions.ToEntity()
+	}
 	existing.UpdatedBy = &actor
 	if err := uc.repo.Update(ctx, existing); err != nil {
 		return nil, err
 	}
+	uc.refreshTriageRules(ctx)
 	return existing, nil
 }
 
@@ -76,5 +86,16 @@ func (uc *AdminRedFlagUsecaseImpl) Delete(ctx context.Context, id string, actor
 	if err := uc.repo.SoftDelete(ctx, id, actor); err != nil {
 		return err
 	}
+	uc.refreshTriageRules(ctx)
 	return nil
 }
+
+// refreshTriageRules invalidates the triage rule set; the periodic refresh retries on failure
+func (uc *AdminRedFlagUsecaseImpl) refreshTriageRules(ctx context.Context) {
+	if uc.rules == nil {
+		return
+	}
+	if err := uc.rules.RefreshRules(ctx); err != nil {
+		log.Printf("❌ Failed to refresh triage rules after red flag change: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/domain/interfaces"
+)
+
+type AdminTriageAuditUsecaseImpl struct {
+	repo  interfaces.TriageAuditRepository
+	rules interfaces.TriageRuleProvider
+}
+
+func NewAdminTriageAuditUsecase(repo interfaces.TriageAuditRepository, rules interfaces.TriageRuleProvider) interfaces.AdminTriageAuditUsecase {
+	return &AdminTriageAuditUsecaseImpl{repo: repo, rules: rules}
+}
+
+func (uc *AdminTriageAuditUsecaseImpl) Search(ctx context.Context, filter dto.TriageAuditFilter) ([]entities.TriageAudit, int64, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return uc.repo.Search(ctx, filter)
+}
+
+func (uc *AdminTriageAuditUsecaseImpl) Get(ctx context.Context, id string) (*entities.TriageAudit, error) {
+	return uc.repo.GetByID(ctx, id)
+}
+
+// RuleStats reports how often each rule fired. Current rules that never fired are
+// included with a zero count so unused rules are visible too.
+func (uc *AdminTriageAuditUsecaseImpl) RuleStats(ctx context.Context, filter dto.TriageAuditFilter) ([]dto.TriageRuleStat, error) {
+	stats, err := uc.repo.RuleStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if uc.rules == nil {
+		return stats, nil
+	}
+
+	snapshot := uc.rules.RuleSnapshot()
+	byID := make(map[string]int, len(stats))
+	for i := range stats {
+		byID[stats[i].RuleID] = i
+	}
+	for _, rule := range append(append([]entities.RedFlagRule{}, snapshot.RedRules...), snapshot.YellowRules...) {
+		if filter.Language != "" && rule.Language != filter.Language {
+			continue
+		}
+		if i, ok := byID[rule.ID]; ok {
+			stats[i].Language = rule.Language
+			continue
+		}
+		stats = append(stats, dto.TriageRuleStat{
+			RuleID:      rule.ID,
+			Description: rule.Description,
+			Level:       rule.Level,
+			Language:    rule.Language,
+		})
+	}
+	return stats, nil
+}
+
+func (uc *AdminTriageAuditUsecaseImpl) GetRuleSnapshot(ctx context.Context, version string) (*entities.TriageRuleSnapshot, error) {
+	return uc.repo.GetRuleSnapshot(ctx, version)
+}
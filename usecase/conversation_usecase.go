@@ -16,6 +16,7 @@ type ConversationUsecaseImpl struct {
 	conversationService interfaces.ConversationService
 	conversationRepo    interfaces.ConversationRepository
 	remedyMateUsecase   interfaces.RemedyMateUsecase
+	messages            interfaces.MessageCatalog
 }
 
 // NewConversationUsecase creates a new conversation usecase
@@ -23,11 +24,13 @@ func NewConversationUsecase(
 	conversationService interfaces.ConversationService,
 	conversationRepo interfaces.ConversationRepository,
 	remedyMateUsecase interfaces.RemedyMateUsecase,
+	messages interfaces.MessageCatalog,
 ) interfaces.ConversationUsecase {
 	return &ConversationUsecaseImpl{
 		conversationService: conversationService,
 		conversationRepo:    conversationRepo,
 		remedyMateUsecase:   remedyMateUsecase,
+		messages:            messages,
 	}
 }
 
@@ -50,6 +53,7 @@ func (cu *ConversationUsecaseImpl) StartConversation(ctx context.Context, req dt
 		UserID:      req.UserID,
 		Symptom:     req.Symptom,
 		Language:    req.Language,
+		Patient:     req.Patient.ToEntity(),
 		Questions:   questions,
 		Answers:     []entities.Answer{},
 		TotalSteps:  len(questions),
@@ -119,6 +123,7 @@ func (cu *ConversationUsecaseImpl) SubmitAnswer(ctx context.Context, req dto.Sub
 			ConversationID: req.ConversationID,
 			Question:       &currentQuestion,
 			Message:        feedback,
+			Language:       conversation.Language,
 			IsComplete:     false,
 			CurrentStep:    conversation.CurrentStep,
 			TotalSteps:     conversation.TotalSteps,
@@ -140,6 +145,7 @@ func (cu *ConversationUsecaseImpl) SubmitAnswer(ctx context.Context, req dto.Sub
 		remedyReq := dto.RemedyRequest{
 			Text:     conversation.Symptom,
 			Language: conversation.Language,
+			Patient:  dto.NewPatientContext(conversation.Patient),
 		}
 		remedyResponse, err := cu.remedyMateUsecase.GetRemedy(ctx, remedyReq)
 
@@ -155,9 +161,10 @@ func (cu *ConversationUsecaseImpl) SubmitAnswer(ctx context.Context, req dto.Sub
 			// Add remedy information to the report
 			report.Remedy = &entities.Remedy{
 				Triage: entities.TriageResult{
-					Level:    remedyResponse.Triage.Level,
-					RedFlags: remedyResponse.Triage.RedFlags,
-					Message:  remedyResponse.Triage.Message,
+					Level:        remedyResponse.Triage.Level,
+					RedFlags:     remedyResponse.Triage.RedFlags,
+					MatchedRules: remedyResponse.Triage.MatchedRules,
+					Message:      remedyResponse.Triage.Message,
 				},
 				SelfCare:      remedyResponse.Content.SelfCare,
 				OTCCategories: remedyResponse.Content.OTCCategories,
@@ -183,7 +190,8 @@ func (cu *ConversationUsecaseImpl) SubmitAnswer(ctx context.Context, req dto.Sub
 		return &dto.SubmitAnswerResponse{
 			ConversationID: req.ConversationID,
 			Question:       nil,
-			Message:        "All questions completed. You can now view your health report and remedy.",
+			Message:        cu.messages.Message(conversation.Language, "conversation.complete.message", nil),
+			Language:       conversation.Language,
 			IsComplete:     true,
 			CurrentStep:    conversation.TotalSteps,
 			TotalSteps:     conversation.TotalSteps,
@@ -202,6 +210,7 @@ func (cu *ConversationUsecaseImpl) SubmitAnswer(ctx context.Context, req dto.Sub
 		ConversationID: req.ConversationID,
 		Question:       &nextQuestion,
 		Message:        "",
+		Language:       conversation.Language,
 		IsComplete:     false,
 		CurrentStep:    conversation.CurrentStep,
 		TotalSteps:     conversation.TotalSteps,
@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	derrors "remedymate-backend/domain/AppError"
@@ -15,15 +17,16 @@ import (
 	"remedymate-backend/util"
 )
 
-var validTopicKeys = []string{
-	"indigestion", "headache", "sore_throat", "cough", "fever", "back_pain",
-}
-
 type RemedyMateUsecase struct {
 	triageService    interfaces.TriageService
 	contentService   interfaces.ContentService
 	guidanceComposer interfaces.GuidanceComposerService
 	mapService       interfaces.MapTopicService
+	localMapper      interfaces.LocalTopicMapper
+	triageAuditRepo  interfaces.TriageAuditRepository
+
+	// savedRuleVersions remembers which rule snapshots were already persisted
+	savedRuleVersions sync.Map
 }
 
 // NewRemedyMateUsecase creates a new RemedyMate usecase
@@ -32,35 +35,95 @@ func NewRemedyMateUsecase(
 	contentService interfaces.ContentService,
 	guidanceComposer interfaces.GuidanceComposerService,
 	mapService interfaces.MapTopicService,
+	localMapper interfaces.LocalTopicMapper,
+	triageAuditRepo interfaces.TriageAuditRepository,
 ) interfaces.RemedyMateUsecase {
 	return &RemedyMateUsecase{
 		triageService:    triageService,
 		contentService:   contentService,
 		guidanceComposer: guidanceComposer,
 		mapService:       mapService,
+		localMapper:      localMapper,
+		triageAuditRepo:  triageAuditRepo,
 	}
 }
 
 // GetTriage performs only triage classification
-func (rmu *RemedyMateUsecase) GetTriage(ctx context.Context, text, lang string) (*dto.TriageResponse, error) {
-	result, err := rmu.triageService.ClassifySymptoms(ctx, text, lang)
+func (rmu *RemedyMateUsecase) GetTriage(ctx context.Context, text, lang string, patient *entities.PatientContext) (*dto.TriageResponse, error) {
+	result, err := rmu.triageService.ClassifySymptoms(ctx, text, lang, patient)
 	if err != nil {
 		return nil, err
 	}
 
+	sessionID := generateSessionID()
+	rmu.recordTriage(ctx, sessionID, text, lang, patient, result)
+
 	return &dto.TriageResponse{
-		Level:     result.Level,
-		RedFlags:  result.RedFlags,
-		Message:   result.Message,
-		SessionID: generateSessionID(),
+		Level:        result.Level,
+		RedFlags:     result.RedFlags,
+		MatchedRules: result.MatchedRules,
+		Message:      result.Message,
+		SessionID:    sessionID,
+		Agreement:    result.Agreement,
+		LLMCalls:     result.LLMCalls,
 	}, nil
 }
 
-// MapTopic maps user symptom input to a valid topic key
+// recordTriage persists the triage outcome for clinical review.
+// Audit failures are logged and never block the user's response.
+func (rmu *RemedyMateUsecase) recordTriage(ctx context.Context, sessionID, text, lang string, patient *entities.PatientContext, result *entities.TriageResult) {
+	if rmu.triageAuditRepo == nil || result == nil {
+		return
+	}
+
+	audit := &entities.TriageAudit{
+		SessionID:    sessionID,
+		InputText:    text,
+		Language:     lang,
+		Patient:      patient,
+		Level:        result.Level,
+		Flags:        result.RedFlags,
+		MatchedRules: result.MatchedRules,
+		Message:      result.Message,
+	}
+	if trace := result.Trace; trace != nil {
+		audit.Source = trace.Source
+		audit.RulesVersion = trace.Rules.Version
+		audit.PromptHash = trace.PromptHash
+		audit.PromptVersion = trace.PromptVersion
+		audit.RawResponse = trace.RawResponse
+		audit.LLMProvider = trace.LLMProvider
+		audit.LLMError = trace.LLMError
+		audit.LatencyMs = trace.LatencyMs
+		audit.LLMCalls = trace.LLMCalls
+		audit.Agreement = trace.Agreement
+		audit.Samples = trace.Samples
+		audit.InjectionPatterns = trace.InjectionPatterns
+
+		if version := trace.Rules.Version; version != "" {
+			if _, saved := rmu.savedRuleVersions.Load(version); !saved {
+				snapshot := trace.Rules
+				if err := rmu.triageAuditRepo.SaveRuleSnapshot(ctx, &snapshot); err != nil {
+					log.Printf("❌ Failed to save triage rule snapshot %s: %v", version, err)
+				} else {
+					rmu.savedRuleVersions.Store(version, true)
+				}
+			}
+		}
+	}
+
+	if err := rmu.triageAuditRepo.Create(ctx, audit); err != nil {
+		log.Printf("❌ Failed to record triage audit: %v", err)
+	}
+}
+
+// MapTopic maps user symptom input to one of the topics currently served. A confident local ranking is
+// used as is; ambiguous input goes to the LLM, and when the LLM fails the best usable
+// local candidate is taken instead. Either mapper may be nil (TOPIC_MAPPER=llm or local).
 func (rmu *RemedyMateUsecase) MapTopic(ctx context.Context, input string) (string, error) {
-	topicKey, err := rmu.mapService.MapSymptomToTopic(ctx, input, validTopicKeys)
+	topicKey, err := rmu.mapTopicKey(ctx, input)
 	if err != nil {
-		return "", fmt.Errorf("failed to map symptom to topic: %w", err)
+		return "", err
 	}
 
 	// If mapping returns an empty topic key, surface a clear error for the controller
@@ -70,7 +133,7 @@ func (rmu *RemedyMateUsecase) MapTopic(ctx context.Context, input string) (strin
 
 	// Validate the returned topic key
 	isValid := false
-	for _, validKey := range validTopicKeys {
+	for _, validKey := range rmu.contentService.TopicKeys() {
 		if topicKey == validKey {
 			isValid = true
 			break
@@ -83,6 +146,31 @@ func (rmu *RemedyMateUsecase) MapTopic(ctx context.Context, input string) (strin
 	return topicKey, nil
 }
 
+func (rmu *RemedyMateUsecase) mapTopicKey(ctx context.Context, input string) (string, error) {
+	topicKeys := rmu.contentService.TopicKeys()
+	var ranking dto.TopicRanking
+	if rmu.localMapper != nil {
+		ranking = rmu.localMapper.Rank(input, topicKeys)
+		if ranking.Confident || (ranking.Usable && rmu.mapService == nil) {
+			return ranking.Candidates[0].TopicKey, nil
+		}
+	}
+	if rmu.mapService == nil {
+		// Local only and nothing matched well enough
+		return "", nil
+	}
+
+	topicKey, err := rmu.mapService.MapSymptomToTopic(ctx, input, topicKeys)
+	if err != nil {
+		if ranking.Usable {
+			log.Printf("⚠️ LLM topic mapping failed, using local candidate %s: %v", ranking.Candidates[0].TopicKey, err)
+			return ranking.Candidates[0].TopicKey, nil
+		}
+		return "", fmt.Errorf("failed to map symptom to topic: %w", err)
+	}
+	return topicKey, nil
+}
+
 // GetContent retrieves approved content for a given topic and language
 func (rmu *RemedyMateUsecase) GetContent(ctx context.Context, topicKey, language string) (*entities.ContentTranslation, error) {
 	// Validate topic key and language
@@ -137,7 +225,8 @@ func (rmu *RemedyMateUsecase) ComposeGuidance(ctx context.Context, req dto.Compo
 // GetRemedy orchestrates triage, topic mapping, content retrieval and LLM composition.
 func (rmu *RemedyMateUsecase) GetRemedy(ctx context.Context, req dto.RemedyRequest) (*dto.RemedyResponse, error) {
 	// 1) Triage
-	triageRes, err := rmu.triageService.ClassifySymptoms(ctx, req.Text, req.Language)
+	patient := req.Patient.ToEntity()
+	triageRes, err := rmu.triageService.ClassifySymptoms(ctx, req.Text, req.Language, patient)
 	if err != nil {
 		return nil, err
 	}
@@ -145,10 +234,14 @@ func (rmu *RemedyMateUsecase) GetRemedy(ctx context.Context, req dto.RemedyReque
 	base := dto.RemedyResponse{
 		SessionID: generateSessionID(),
 	}
+	rmu.recordTriage(ctx, base.SessionID, req.Text, req.Language, patient, triageRes)
 	base.Triage = dto.TriageResponse{
-		Level:    triageRes.Level,
-		RedFlags: triageRes.RedFlags,
-		Message:  triageRes.Message,
+		Level:        triageRes.Level,
+		RedFlags:     triageRes.RedFlags,
+		MatchedRules: triageRes.MatchedRules,
+		Message:      triageRes.Message,
+		Agreement:    triageRes.Agreement,
+		LLMCalls:     triageRes.LLMCalls,
 	}
 
 	// If RED, return early with triage only
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// draftVersion names the pending change when comparing versions
+const draftVersion = "draft"
+
+// ListRevisions returns the published versions of a topic, which are kept after it is deleted
+func (tu *TopicUsecase) ListRevisions(ctx context.Context, topicKey string) ([]entities.TopicRevision, error) {
+	if _, err := tu.topicRepository.GetTopicIncludingDeleted(ctx, topicKey); err != nil {
+		return nil, err
+	}
+	return tu.revisions.List(ctx, topicKey)
+}
+
+func (tu *TopicUsecase) GetRevision(ctx context.Context, topicKey string, version int) (*entities.TopicRevision, error) {
+	return tu.revisions.Get(ctx, topicKey, version)
+}
+
+// DiffRevisions compares two versions of a topic field by field. A version is a published
+// version number or "draft" for the change waiting to be published.
+func (tu *TopicUsecase) DiffRevisions(ctx context.Context, topicKey, from, to string) (*dto.TopicDiff, error) {
+	topic, err := tu.topicRepository.GetTopicByKey(ctx, topicKey)
+	if err != nil {
+		return nil, err
+	}
+	fromContent, err := tu.versionContent(ctx, topic, from)
+	if err != nil {
+		return nil, err
+	}
+	toContent, err := tu.versionContent(ctx, topic, to)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TopicDiff{
+		TopicKey: topicKey,
+		From:     from,
+		To:       to,
+		Changes:  diffTopicContent(fromContent, toContent),
+	}, nil
+}
+
+// versionContent resolves a version number or "draft" to the content it had
+func (tu *TopicUsecase) versionContent(ctx context.Context, topic *entities.Topic, version string) (entities.TopicContent, error) {
+	if version == draftVersion {
+		if topic.Draft == nil {
+			return entities.TopicContent{}, fmt.Errorf("%w: topic %s has no draft", AppError.ErrTopicRevisionNotFound, topic.TopicKey)
+		}
+		return topic.Draft.TopicContent, nil
+	}
+	number, err := strconv.Atoi(version)
+	if err != nil || number < 1 {
+		return entities.TopicContent{}, fmt.Errorf("%w: version must be a number or %q", AppError.ErrInvalidInput, draftVersion)
+	}
+	revision, err := tu.revisions.Get(ctx, topic.TopicKey, number)
+	if errors.Is(err, AppError.ErrTopicRevisionNotFound) && number == topic.Version {
+		// Published before revisions were kept and not changed since
+		return topic.Content(), nil
+	}
+	if err != nil {
+		return entities.TopicContent{}, err
+	}
+	return revision.Content, nil
+}
+
+// RollbackTopic copies the content of an earlier version into a new draft and submits it for
+// review, so a rollback is approved by someone other than the admin who asked for it before it
+// is published as a new version. A version with incomplete required translations is refused
+// here rather than at publication. A topic with a pending draft is not rolled back, since the
+// draft would be lost; archived topics are reopened through review instead.
+func (tu *TopicUsecase) RollbackTopic(ctx context.Context, topicKey string, version int, notes string) (*entities.Topic, error) {
+	actorID, err := extractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actorOID, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return nil, AppError.ErrInvalidInput
+	}
+
+	existing, err := tu.loadForEditing(ctx, topicKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing.EditorialState() == entities.TopicStateArchived || existing.PublishedAt == nil {
+		return nil, fmt.Errorf("%w: only a served topic can be rolled back", AppError.ErrInvalidTopicTransition)
+	}
+	if existing.Draft != nil {
+		return nil, fmt.Errorf("%w: topic %s has a pending draft", AppError.ErrInvalidTopicTransition, topicKey)
+	}
+	if version == existing.Version {
+		return nil, fmt.Errorf("%w: version %d is already published", AppError.ErrInvalidInput, version)
+	}
+	revision, err := tu.revisions.Get(ctx, topicKey, version)
+	if err != nil {
+		return nil, err
+	}
+	// Versions published before a language was required may lack it
+	if err := tu.checkRequiredLanguages(revision.Content); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	historyNotes := fmt.Sprintf("rolled back to version %d", version)
+	if notes != "" {
+		historyNotes += ": " + notes
+	}
+	existing.Draft = &entities.TopicDraft{
+		TopicContent: revision.Content,
+		Authors:      []primitive.ObjectID{actorOID},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		RestoredFrom: version,
+	}
+	existing.Transitions = append(existing.Transitions, entities.TopicTransition{
+		From: existing.EditorialState(), To: entities.TopicStateInReview, Actor: actorOID, At: now, Notes: historyNotes,
+	})
+	existing.State = entities.TopicStateInReview
+	existing.UpdatedAt = now
+	existing.UpdatedBy = actorOID
+	if err := tu.topicRepository.UpdateTopic(ctx, topicKey, existing); err != nil {
+		return nil, err
+	}
+
+	return tu.topicRepository.GetTopicByKey(ctx, topicKey)
+}
+
+// ServedAt reports the version users were served at the given time: the last revision
+// published by then, unless the topic was archived or deleted after it. Deleted topics are
+// included, since their revisions are kept.
+func (tu *TopicUsecase) ServedAt(ctx context.Context, topicKey string, at time.Time) (*dto.TopicServedAt, error) {
+	topic, err := tu.topicRepository.GetTopicIncludingDeleted(ctx, topicKey)
+	if err != nil {
+		return nil, err
+	}
+	result := &dto.TopicServedAt{TopicKey: topicKey, At: at}
+	revision, err := tu.revisions.LatestAt(ctx, topicKey, at)
+	if errors.Is(err, AppError.ErrTopicRevisionNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Revision = revision
+	result.Served = true
+	takenDown := func(when time.Time) bool {
+		return when.After(revision.PublishedAt) && !when.After(at)
+	}
+	deletionRecorded := false
+	for _, transition := range topic.Transitions {
+		if transition.To == entities.TopicStateArchived && takenDown(transition.At) {
+			result.Served = false
+		}
+		deletionRecorded = deletionRecorded || transition.Notes == topicDeletedNote
+	}
+	// A topic deleted before deletions were recorded as transitions was last updated when it was deleted
+	if topic.Status == entities.TopicStatusDeleted && !deletionRecorded && takenDown(topic.UpdatedAt) {
+		result.Served = false
+	}
+	return result, nil
+}
+
+// ensureRevision stores the content currently served as a revision if it has none, which is
+// the case for topics published before revisions were kept or when saving one failed
+func (tu *TopicUsecase) ensureRevision(ctx context.Context, topic *entities.Topic) error {
+	if topic.Version < 1 || topic.PublishedAt == nil {
+		return nil
+	}
+	_, err := tu.revisions.Get(ctx, topic.TopicKey, topic.Version)
+	if !errors.Is(err, AppError.ErrTopicRevisionNotFound) {
+		return err
+	}
+	return tu.revisions.Create(ctx, &entities.TopicRevision{
+		TopicKey:    topic.TopicKey,
+		Version:     topic.Version,
+		Content:     topic.Content(),
+		PublishedAt: *topic.PublishedAt,
+		PublishedBy: topic.PublishedBy,
+		Notes:       "recorded before the next change",
+	})
+}
+
+// saveRevision stores the version just published. The topic is already updated, so a failure
+// is logged and left to ensureRevision to fill in before the next change.
+func (tu *TopicUsecase) saveRevision(ctx context.Context, topic *entities.Topic, notes string, restoredFrom int) {
+	err := tu.revisions.Create(ctx, &entities.TopicRevision{
+		TopicKey:     topic.TopicKey,
+		Version:      topic.Version,
+		Content:      topic.Content(),
+		PublishedAt:  *topic.PublishedAt,
+		PublishedBy:  topic.PublishedBy,
+		Notes:        notes,
+		RestoredFrom: restoredFrom,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to save revision %d of topic %s: %v", topic.Version, topic.TopicKey, err)
+	}
+}
+
+// diffTopicContent lists the fields that differ between two versions, in field order
+func diffTopicContent(from, to entities.TopicContent) []dto.TopicFieldChange {
+	before, after := flattenTopicContent(from), flattenTopicContent(to)
+	fields := make([]string, 0, len(before)+len(after))
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]dto.TopicFieldChange, 0)
+	for _, field := range fields {
+		old, new := before[field], after[field]
+		if reflect.DeepEqual(old, new) {
+			continue
+		}
+		change := dto.TopicFieldChange{Field: field, From: old, To: new}
+		oldItems, oldList := old.([]string)
+		newItems, newList := new.([]string)
+		if oldList || newList {
+			change.Added = itemsNotIn(newItems, oldItems)
+			change.Removed = itemsNotIn(oldItems, newItems)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// flattenTopicContent maps each non-empty field to its JSON path
+func flattenTopicContent(content entities.TopicContent) map[string]interface{} {
+	fields := make(map[string]interface{})
+	setString := func(field, value string) {
+		if value != "" {
+			fields[field] = value
+		}
+	}
+	setList := func(field string, items []string) {
+		if len(items) > 0 {
+			fields[field] = items
+		}
+	}
+
+	setString("name_en", content.NameEN)
+	setString("name_am", content.NameAM)
+	setString("description_en", content.DescriptionEN)
+	setString("description_am", content.DescriptionAM)
+	for code, synonyms := range content.Synonyms {
+		setList("synonyms."+code, synonyms)
+	}
+	for code, translation := range content.Translations {
+		prefix := "translations." + code + "."
+		setList(prefix+"self_care", translation.SelfCare)
+		setList(prefix+"seek_care_if", translation.SeekCareIf)
+		setString(prefix+"disclaimer", translation.Disclaimer)
+		if len(translation.OTCCategories) > 0 {
+			fields[prefix+"otc_categories"] = translation.OTCCategories
+		}
+	}
+	return fields
+}
+
+// itemsNotIn returns the items that are not in other
+func itemsNotIn(items, other []string) []string {
+	present := make(map[string]bool, len(other))
+	for _, item := range other {
+		present[item] = true
+	}
+	var missing []string
+	for _, item := range items {
+		if !present[item] {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
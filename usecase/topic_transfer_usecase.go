@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/util/lang"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportPageSize is how many topics an export reads per query
+const exportPageSize = 100
+
+var topicKeyPattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// ExportTopics returns the topics selected by the query, ordered by key. Each record holds the
+// content being edited (the draft when there is one) and the published version, so an
+// unchanged file imports without changes.
+func (tu *TopicUsecase) ExportTopics(ctx context.Context, query dto.TopicExportQuery) ([]dto.TopicRecord, error) {
+	for _, code := range query.Languages {
+		if !lang.IsSupported(code) {
+			return nil, fmt.Errorf("%w: unsupported language %q", AppError.ErrInvalidInput, code)
+		}
+	}
+	wanted := make(map[string]bool, len(query.TopicKeys))
+	for _, key := range query.TopicKeys {
+		wanted[key] = true
+	}
+
+	records := make([]dto.TopicRecord, 0)
+	params := dto.TopicListQueryParams{
+		PaginationQueryParams: dto.PaginationQueryParams{Page: 1, Limit: exportPageSize},
+		SortQueryParams:       dto.SortQueryParams{SortBy: "topic_key", Order: "asc"},
+		State:                 query.State,
+	}
+	for seen := 0; ; params.Page++ {
+		topics, total, err := tu.topicRepository.ListAllTopics(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, topic := range topics {
+			if len(wanted) > 0 && !wanted[topic.TopicKey] {
+				continue
+			}
+			content := topic.Content()
+			if topic.Draft != nil {
+				content = topic.Draft.TopicContent
+			}
+			if len(query.Languages) > 0 {
+				content.Synonyms = onlyLanguages(content.Synonyms, query.Languages)
+				content.Translations = onlyLanguages(content.Translations, query.Languages)
+			}
+			records = append(records, dto.TopicRecord{
+				TopicKey:     topic.TopicKey,
+				Version:      topic.Version,
+				State:        topic.EditorialState(),
+				TopicContent: content,
+			})
+		}
+		seen += len(topics)
+		if len(topics) == 0 || int64(seen) >= total {
+			break
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].TopicKey < records[j].TopicKey })
+	return records, nil
+}
+
+// ImportTopics upserts topics by key. Imported content goes to the topic's draft, merged per
+// language so a file with only Amharic columns leaves English alone; the draft is reviewed and
+// published as usual, which bumps the version. A row exported before the topic was published
+// again is rejected rather than overwriting the newer content.
+//
+// Every row is checked first and nothing is written when it is a dry run or any row is invalid,
+// so a corrected file can be imported as a whole.
+func (tu *TopicUsecase) ImportTopics(ctx context.Context, records []dto.TopicRecord, dryRun bool) (*dto.TopicImportReport, error) {
+	actorID, err := extractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := primitive.ObjectIDFromHex(actorID); err != nil {
+		return nil, AppError.ErrInvalidInput
+	}
+
+	report := &dto.TopicImportReport{DryRun: dryRun, Rows: make([]dto.TopicImportRow, 0, len(records))}
+	merged := make([]entities.TopicContent, len(records))
+	rowOf := make(map[string]int, len(records))
+	for i, record := range records {
+		row := dto.TopicImportRow{Row: i + 1, TopicKey: record.TopicKey, Errors: validateTopicRecord(record)}
+		if first, dup := rowOf[record.TopicKey]; dup && record.TopicKey != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("topic_key repeats row %d", first))
+		} else {
+			rowOf[record.TopicKey] = row.Row
+		}
+
+		if len(row.Errors) == 0 {
+			if err := tu.planImportRow(ctx, record, &row, &merged[i]); err != nil {
+				return nil, err
+			}
+		}
+		if len(row.Errors) > 0 {
+			row.Action = dto.TopicImportInvalid
+			row.Changes = nil
+		}
+
+		switch row.Action {
+		case dto.TopicImportCreated:
+			report.Created++
+		case dto.TopicImportUpdated:
+			report.Updated++
+		case dto.TopicImportUnchanged:
+			report.Unchanged++
+		default:
+			report.Invalid++
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	if dryRun || report.Invalid > 0 {
+		return report, nil
+	}
+
+	for i, row := range report.Rows {
+		content := merged[i]
+		var err error
+		switch row.Action {
+		case dto.TopicImportCreated:
+			_, err = tu.CreateTopic(ctx, dto.TopicCreateRequest{
+				TopicKey:      row.TopicKey,
+				NameEN:        content.NameEN,
+				NameAM:        content.NameAM,
+				DescriptionEN: content.DescriptionEN,
+				DescriptionAM: content.DescriptionAM,
+				Synonyms:      content.Synonyms,
+				Translations:  content.Translations,
+			})
+		case dto.TopicImportUpdated:
+			_, err = tu.UpdateTopic(ctx, row.TopicKey, dto.TopicUpdateRequest{
+				NameEN:        content.NameEN,
+				NameAM:        content.NameAM,
+				DescriptionEN: content.DescriptionEN,
+				DescriptionAM: content.DescriptionAM,
+				Synonyms:      content.Synonyms,
+				Translations:  content.Translations,
+			})
+		}
+		if err != nil {
+			// Rows before this one are already saved as drafts
+			return nil, fmt.Errorf("import stopped at row %d (%s): %w", row.Row, row.TopicKey, err)
+		}
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// planImportRow works out what importing a valid row does and the content it leaves in the draft
+func (tu *TopicUsecase) planImportRow(ctx context.Context, record dto.TopicRecord, row *dto.TopicImportRow, merged *entities.TopicContent) error {
+	existing, err := tu.topicRepository.GetTopicByKey(ctx, record.TopicKey)
+	if errors.Is(err, AppError.ErrTopicNotFound) {
+		deleted, err := tu.topicRepository.CheckTopicExists(ctx, record.TopicKey)
+		if err != nil {
+			return err
+		}
+		switch {
+		case deleted:
+			row.Errors = append(row.Errors, "topic was deleted and cannot be imported again")
+		case record.NameEN == "" || record.NameAM == "":
+			row.Errors = append(row.Errors, "name_en and name_am are required for a new topic")
+		case record.Version > 0:
+			row.Errors = append(row.Errors, "topic does not exist; leave version empty for a new topic")
+		}
+		*merged = mergeTopicContent(entities.TopicContent{}, record.TopicContent)
+		row.Errors = append(row.Errors, validateImportedContent(record, *merged)...)
+		row.Action = dto.TopicImportCreated
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if record.Version > 0 && record.Version != existing.Version {
+		row.Errors = append(row.Errors, fmt.Sprintf("exported from version %d but the topic is now at version %d; export it again", record.Version, existing.Version))
+	}
+	current := existing.Content()
+	if existing.Draft != nil {
+		current = existing.Draft.TopicContent
+	}
+	*merged = mergeTopicContent(current, record.TopicContent)
+	row.Errors = append(row.Errors, validateImportedContent(record, *merged)...)
+	row.Version = existing.Version
+	row.Changes = diffTopicContent(current, *merged)
+	row.Action = dto.TopicImportUpdated
+	if len(row.Changes) == 0 {
+		row.Action = dto.TopicImportUnchanged
+	}
+	return nil
+}
+
+// validateTopicRecord lists what is wrong with a row on its own
+func validateTopicRecord(record dto.TopicRecord) []string {
+	var problems []string
+	if !topicKeyPattern.MatchString(record.TopicKey) {
+		problems = append(problems, "topic_key must be lowercase letters and digits separated by underscores")
+	}
+	if record.Version < 0 {
+		problems = append(problems, "version must not be negative")
+	}
+	for _, code := range sortedKeys(record.Synonyms) {
+		if !lang.IsSupported(code) {
+			problems = append(problems, fmt.Sprintf("synonyms: unsupported language %q", code))
+		}
+	}
+	for _, code := range sortedKeys(record.Translations) {
+		if !lang.IsSupported(code) {
+			problems = append(problems, fmt.Sprintf("translations: unsupported language %q", code))
+			continue
+		}
+		for _, otc := range record.Translations[code].OTCCategories {
+			if otc.CategoryName == "" {
+				problems = append(problems, fmt.Sprintf("translations.%s.otc_categories has a category without a name", code))
+			}
+		}
+	}
+	return problems
+}
+
+// validateImportedContent checks the languages a row touches once it is merged into the topic
+func validateImportedContent(record dto.TopicRecord, merged entities.TopicContent) []string {
+	var problems []string
+	for _, code := range sortedKeys(record.Translations) {
+		content := merged.Translations[code]
+		if len(content.SelfCare) == 0 {
+			problems = append(problems, fmt.Sprintf("translations.%s.self_care is empty", code))
+		}
+		if content.Disclaimer == "" {
+			problems = append(problems, fmt.Sprintf("translations.%s.disclaimer is empty", code))
+		}
+	}
+	return problems
+}
+
+// mergeTopicContent applies an imported row to the current content: non-empty fields replace
+// the current ones, synonyms are replaced per language and translations per field
+func mergeTopicContent(current, update entities.TopicContent) entities.TopicContent {
+	merged := current
+	if update.NameEN != "" {
+		merged.NameEN = update.NameEN
+	}
+	if update.NameAM != "" {
+		merged.NameAM = update.NameAM
+	}
+	if update.DescriptionEN != "" {
+		merged.DescriptionEN = update.DescriptionEN
+	}
+	if update.DescriptionAM != "" {
+		merged.DescriptionAM = update.DescriptionAM
+	}
+	merged.Synonyms = mergeLanguages(current.Synonyms, update.Synonyms)
+	merged.Translations = mergeTranslations(current.Translations, update.Translations)
+	return merged
+}
+
+// mergeTranslations returns current with the non-empty fields of update applied
+func mergeTranslations(current, update map[string]entities.LocalizedGuidanceContent) map[string]entities.LocalizedGuidanceContent {
+	if len(update) == 0 {
+		return current
+	}
+	merged := make(map[string]entities.LocalizedGuidanceContent, len(current)+len(update))
+	for code, content := range current {
+		merged[code] = content
+	}
+	for code, fields := range update {
+		content := merged[code]
+		if len(fields.SelfCare) > 0 {
+			content.SelfCare = fields.SelfCare
+		}
+		if len(fields.OTCCategories) > 0 {
+			content.OTCCategories = fields.OTCCategories
+		}
+		if len(fields.SeekCareIf) > 0 {
+			content.SeekCareIf = fields.SeekCareIf
+		}
+		if fields.Disclaimer != "" {
+			content.Disclaimer = fields.Disclaimer
+		}
+		merged[code] = content
+	}
+	return merged
+}
+
+// mergeLanguages returns current with the languages in update replaced
+func mergeLanguages[T any](current, update map[string]T) map[string]T {
+	if len(update) == 0 {
+		return current
+	}
+	merged := make(map[string]T, len(current)+len(update))
+	for code, value := range current {
+		merged[code] = value
+	}
+	for code, value := range update {
+		merged[code] = value
+	}
+	return merged
+}
+
+// onlyLanguages keeps the given languages of a per-language map
+func onlyLanguages[T any](values map[string]T, codes []string) map[string]T {
+	kept := make(map[string]T, len(codes))
+	for _, code := range codes {
+		if value, ok := values[code]; ok {
+			kept[code] = value
+		}
+	}
+	return kept
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/util/lang"
+)
+
+// maxForeignWords caps the examples given for text in the wrong script
+const maxForeignWords = 5
+
+// TranslationReport checks every active topic in the enabled languages, or in the given ones.
+// The content being edited is checked, which is the published content unless there is a draft.
+func (tu *TopicUsecase) TranslationReport(ctx context.Context, languages []string) (*dto.TranslationReport, error) {
+	if len(languages) == 0 {
+		languages = lang.Codes()
+	}
+	checked, err := registryLanguages(languages)
+	if err != nil {
+		return nil, err
+	}
+	records, err := tu.ExportTopics(ctx, dto.TopicExportQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.TranslationReport{
+		GeneratedAt:       time.Now(),
+		Languages:         languages,
+		RequiredLanguages: tu.publish.RequiredLanguages,
+		Problems:          make(map[string]int),
+		Topics:            make([]dto.TopicTranslationStatus, 0),
+	}
+	if report.RequiredLanguages == nil {
+		report.RequiredLanguages = []string{}
+	}
+	for _, record := range records {
+		if record.State == entities.TopicStateArchived {
+			continue
+		}
+		report.TopicsChecked++
+		status := dto.TopicTranslationStatus{TopicKey: record.TopicKey, State: record.State, Version: record.Version}
+		status.Issues = translationIssues(record.TopicContent, checked)
+		for _, issue := range status.Issues {
+			report.Problems[issue.Problem]++
+			if issue.Severity == dto.TranslationError {
+				status.Errors++
+			} else {
+				status.Warnings++
+			}
+		}
+		if status.Errors == 0 {
+			report.TopicsComplete++
+		}
+		if len(status.Issues) > 0 {
+			report.Topics = append(report.Topics, status)
+		}
+	}
+	return report, nil
+}
+
+// checkRequiredLanguages rejects content whose required translations are incomplete
+func (tu *TopicUsecase) checkRequiredLanguages(content entities.TopicContent) error {
+	if len(tu.publish.RequiredLanguages) == 0 {
+		return nil
+	}
+	required, err := registryLanguages(tu.publish.RequiredLanguages)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, issue := range translationIssues(content, required) {
+		if issue.Severity == dto.TranslationError {
+			problems = append(problems, fmt.Sprintf("%s (%s)", issue.Field, issue.Problem))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", AppError.ErrIncompleteTranslation, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// registryLanguages looks up enabled languages by code
+func registryLanguages(codes []string) ([]lang.Language, error) {
+	languages := make([]lang.Language, 0, len(codes))
+	for _, code := range codes {
+		language, ok := lang.Default().Get(code)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported language %q", AppError.ErrInvalidInput, code)
+		}
+		languages = append(languages, language)
+	}
+	return languages, nil
+}
+
+// translationIssues checks a topic's content in each language. A missing translation, empty
+// self-care or seek-care advice and a missing disclaimer are errors; a different number of OTC
+// categories than the default language and letters outside the language's script are warnings.
+func translationIssues(content entities.TopicContent, languages []lang.Language) []dto.TranslationIssue {
+	defaultCode := lang.Default().DefaultCode()
+	issues := make([]dto.TranslationIssue, 0)
+	add := func(code, field, problem, severity, detail string) {
+		issues = append(issues, dto.TranslationIssue{Language: code, Field: field, Problem: problem, Severity: severity, Detail: detail})
+	}
+
+	for _, language := range languages {
+		code := language.Code
+		prefix := "translations." + code
+		translation, ok := content.Translations[code]
+		if !ok {
+			add(code, prefix, dto.TranslationMissing, dto.TranslationError, "")
+		} else {
+			if len(translation.SelfCare) == 0 {
+				add(code, prefix+".self_care", dto.TranslationEmptySelfCare, dto.TranslationError, "")
+			}
+			if len(translation.SeekCareIf) == 0 {
+				add(code, prefix+".seek_care_if", dto.TranslationEmptySeekCareIf, dto.TranslationError, "")
+			}
+			if strings.TrimSpace(translation.Disclaimer) == "" {
+				add(code, prefix+".disclaimer", dto.TranslationNoDisclaimer, dto.TranslationError, "")
+			}
+			if base, ok := content.Translations[defaultCode]; ok && code != defaultCode && len(base.OTCCategories) != len(translation.OTCCategories) {
+				add(code, prefix+".otc_categories", dto.TranslationOTCCountMismatch, dto.TranslationWarning,
+					fmt.Sprintf("%d categories, %s has %d", len(translation.OTCCategories), defaultCode, len(base.OTCCategories)))
+			}
+		}
+
+		script, ok := unicode.Scripts[language.Script]
+		if !ok {
+			continue
+		}
+		for _, field := range languageFields(content, code) {
+			if words := foreignWords(field.texts, script); len(words) > 0 {
+				add(code, field.path, dto.TranslationWrongScript, dto.TranslationWarning,
+					fmt.Sprintf("not %s script: %s", language.Script, strings.Join(words, ", ")))
+			}
+		}
+	}
+	return issues
+}
+
+// languageField is the text of one field written in a single language
+type languageField struct {
+	path  string
+	texts []string
+}
+
+// languageFields returns the fields of the content written in the given language, in a fixed order
+func languageFields(content entities.TopicContent, code string) []languageField {
+	var fields []languageField
+	add := func(path string, texts ...string) {
+		fields = append(fields, languageField{path: path, texts: texts})
+	}
+	add("name_"+code, content.Names()[code])
+	add("description_"+code, content.Descriptions()[code])
+	add("synonyms."+code, content.Synonyms[code]...)
+
+	translation := content.Translations[code]
+	prefix := "translations." + code + "."
+	add(prefix+"self_care", translation.SelfCare...)
+	otc := make([]string, 0, 2*len(translation.OTCCategories))
+	for _, category := range translation.OTCCategories {
+		otc = append(otc, category.CategoryName, category.SafetyNote)
+	}
+	add(prefix+"otc_categories", otc...)
+	add(prefix+"seek_care_if", translation.SeekCareIf...)
+	add(prefix+"disclaimer", translation.Disclaimer)
+	return fields
+}
+
+// foreignWords returns the distinct words with letters outside the script, up to maxForeignWords.
+// Single letters are skipped: they are units such as the C in 38°C.
+func foreignWords(texts []string, script *unicode.RangeTable) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsMark(r) }) {
+			if seen[word] || utf8.RuneCountInString(word) < 2 {
+				continue
+			}
+			for _, r := range word {
+				if unicode.IsLetter(r) && !unicode.Is(script, r) {
+					seen[word] = true
+					words = append(words, word)
+					break
+				}
+			}
+			if len(words) == maxForeignWords {
+				return words
+			}
+		}
+	}
+	return words
+}
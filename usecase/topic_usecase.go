@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"time"
 
 	"remedymate-backend/domain/AppError"
@@ -12,13 +14,26 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// topicDeletedNote marks the transition recorded when a topic is deleted
+const topicDeletedNote = "deleted"
+
 type TopicUsecase struct {
 	topicRepository interfaces.TopicRepository
+	revisions       interfaces.TopicRevisionRepository
+	content         interfaces.TopicContentProvider
+	publish         dto.TopicPublishConfig
 }
 
-func NewTopicUsecase(topicRepo interfaces.TopicRepository) *TopicUsecase {
+// NewTopicUsecase creates the topic usecase. Every published version is kept in the revision
+// repository, and publications are pushed to the content provider so they reach users without
+// a restart; content may be nil. Publishing requires complete translations in the languages
+// the publish config lists.
+func NewTopicUsecase(topicRepo interfaces.TopicRepository, revisions interfaces.TopicRevisionRepository, content interfaces.TopicContentProvider, publish dto.TopicPublishConfig) *TopicUsecase {
 	return &TopicUsecase{
 		topicRepository: topicRepo,
+		revisions:       revisions,
+		content:         content,
+		publish:         publish,
 	}
 }
 
@@ -43,20 +58,33 @@ func (tu *TopicUsecase) CreateTopic(ctx context.Context, request dto.TopicCreate
 		return nil, AppError.ErrTopicAlreadyExists
 	}
 
+	// A new topic starts as a draft and is not served until it is reviewed and published.
+	// The names are set on the topic as well so admin lists can show it.
 	now := time.Now()
 	topic := &entities.Topic{
-		TopicKey:      request.TopicKey,
-		NameEN:        request.NameEN,
-		NameAM:        request.NameAM,
-		DescriptionEN: request.DescriptionEN,
-		DescriptionAM: request.DescriptionAM,
-		Status:        entities.TopicStatusActive,
-		Translations:  request.Translations,
-		Version:       1,
-		CreatedAt:     now,
-		UpdatedAt:     now,
-		CreatedBy:     createdByOID,
-		UpdatedBy:     createdByOID,
+		TopicKey: request.TopicKey,
+		NameEN:   request.NameEN,
+		NameAM:   request.NameAM,
+		Status:   entities.TopicStatusActive,
+		State:    entities.TopicStateDraft,
+		Draft: &entities.TopicDraft{
+			TopicContent: entities.TopicContent{
+				NameEN:        request.NameEN,
+				NameAM:        request.NameAM,
+				DescriptionEN: request.DescriptionEN,
+				DescriptionAM: request.DescriptionAM,
+				Synonyms:      request.Synonyms,
+				Translations:  request.Translations,
+			},
+			Authors:   []primitive.ObjectID{createdByOID},
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Transitions: []entities.TopicTransition{{To: entities.TopicStateDraft, Actor: createdByOID, At: now, Notes: "created"}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   createdByOID,
+		UpdatedBy:   createdByOID,
 	}
 
 	if err := tu.topicRepository.CreateTopic(ctx, topic); err != nil {
@@ -141,25 +169,51 @@ func (tu *TopicUsecase) UpdateTopic(ctx context.Context, topicKey string, reques
 	}
 
 	// Ensure topic exists
-	existing, err := tu.topicRepository.GetTopicByKey(ctx, topicKey)
+	existing, err := tu.loadForEditing(ctx, topicKey)
 	if err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return nil, AppError.ErrTopicNotFound
-	}
 
-	// Apply allowed updates and preserve CreatedAt/CreatedBy
-	existing.NameEN = request.NameEN
-	existing.NameAM = request.NameAM
-	existing.DescriptionEN = request.DescriptionEN
-	existing.DescriptionAM = request.DescriptionAM
+	// Changes go to the draft; the published content stays live until the draft is published
+	now := time.Now()
+	draft := existing.Draft
+	if draft == nil {
+		draft = &entities.TopicDraft{TopicContent: existing.Content(), CreatedAt: now}
+	}
+	if request.NameEN != "" {
+		draft.NameEN = request.NameEN
+	}
+	if request.NameAM != "" {
+		draft.NameAM = request.NameAM
+	}
+	if request.DescriptionEN != "" {
+		draft.DescriptionEN = request.DescriptionEN
+	}
+	if request.DescriptionAM != "" {
+		draft.DescriptionAM = request.DescriptionAM
+	}
 	if request.Translations != nil {
-		existing.Translations = request.Translations
+		draft.Translations = request.Translations
 	}
-	existing.UpdatedAt = time.Now()
+	if request.Synonyms != nil {
+		draft.Synonyms = request.Synonyms
+	}
+	draft.UpdatedAt = now
+	draft.RestoredFrom = 0
+	if !containsObjectID(draft.Authors, updatedByOID) {
+		draft.Authors = append(draft.Authors, updatedByOID)
+	}
+	existing.Draft = draft
+
+	// Editing a change that was in review or approved sends it back to draft
+	if from := existing.EditorialState(); from != entities.TopicStateDraft {
+		existing.State = entities.TopicStateDraft
+		existing.Transitions = append(existing.Transitions, entities.TopicTransition{
+			From: from, To: entities.TopicStateDraft, Actor: updatedByOID, At: now, Notes: "edited",
+		})
+	}
+	existing.UpdatedAt = now
 	existing.UpdatedBy = updatedByOID
-	existing.Version = existing.Version + 1
 
 	if err := tu.topicRepository.UpdateTopic(ctx, topicKey, existing); err != nil {
 		return nil, err
@@ -172,19 +226,180 @@ func (tu *TopicUsecase) UpdateTopic(ctx context.Context, topicKey string, reques
 	return updated, nil
 }
 
+// TransitionTopic moves a topic through the editorial workflow:
+//
+//	draft → in_review → approved → published, with in_review/approved → draft to send a
+//	change back (notes required), any state → archived, and archived → draft to reopen.
+//
+// The approver must not be one of the draft's authors, and a draft with incomplete required
+// translations is not published. Publishing copies the draft into the served content, bumps
+// the version and stores the new version as a revision, noting the version a rollback restored;
+// publishing and archiving take effect
+// for users immediately.
+func (tu *TopicUsecase) TransitionTopic(ctx context.Context, topicKey string, request dto.TopicTransitionRequest) (*entities.Topic, error) {
+	actorID, err := extractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actorOID, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return nil, AppError.ErrInvalidInput
+	}
+
+	existing, err := tu.loadForEditing(ctx, topicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := existing.EditorialState(), request.To
+	invalid := fmt.Errorf("%w: %s to %s", AppError.ErrInvalidTopicTransition, from, to)
+	now := time.Now()
+	restoredFrom := 0
+	switch to {
+	case entities.TopicStateInReview:
+		if from != entities.TopicStateDraft || existing.Draft == nil {
+			return nil, invalid
+		}
+	case entities.TopicStateApproved:
+		if from != entities.TopicStateInReview {
+			return nil, invalid
+		}
+		if containsObjectID(existing.Draft.Authors, actorOID) {
+			return nil, AppError.ErrSelfReview
+		}
+	case entities.TopicStatePublished:
+		if from != entities.TopicStateApproved {
+			return nil, invalid
+		}
+		if err := tu.checkRequiredLanguages(existing.Draft.TopicContent); err != nil {
+			return nil, err
+		}
+		if err := tu.ensureRevision(ctx, existing); err != nil {
+			return nil, err
+		}
+		restoredFrom = existing.Draft.RestoredFrom
+		existing.SetContent(existing.Draft.TopicContent)
+		existing.Draft = nil
+		existing.Version++
+		existing.PublishedAt = &now
+		existing.PublishedBy = actorOID
+		existing.RevisionHistory = append(existing.RevisionHistory, entities.RevisionEntry{
+			Version: existing.Version, Notes: request.Notes, ChangedAt: now, ChangedBy: actorOID,
+		})
+	case entities.TopicStateDraft:
+		switch from {
+		case entities.TopicStateInReview, entities.TopicStateApproved:
+			// Sending a change back needs a reason for the authors
+			if request.Notes == "" {
+				return nil, fmt.Errorf("%w: notes are required when sending a change back to draft", AppError.ErrInvalidInput)
+			}
+		case entities.TopicStateArchived:
+			if existing.Draft == nil {
+				existing.Draft = &entities.TopicDraft{TopicContent: existing.Content(), CreatedAt: now, UpdatedAt: now}
+			}
+		default:
+			return nil, invalid
+		}
+	case entities.TopicStateArchived:
+		if from == entities.TopicStateArchived {
+			return nil, invalid
+		}
+		// Reopening needs a new review and publication before the topic is served again
+		existing.PublishedAt = nil
+		existing.PublishedBy = primitive.NilObjectID
+	default:
+		return nil, fmt.Errorf("%w: unknown topic state %q", AppError.ErrInvalidInput, to)
+	}
+
+	existing.State = to
+	existing.Transitions = append(existing.Transitions, entities.TopicTransition{
+		From: from, To: to, Actor: actorOID, At: now, Notes: request.Notes,
+	})
+	existing.UpdatedAt = now
+	existing.UpdatedBy = actorOID
+	if err := tu.topicRepository.UpdateTopic(ctx, topicKey, existing); err != nil {
+		return nil, err
+	}
+	if to == entities.TopicStatePublished {
+		tu.saveRevision(ctx, existing, request.Notes, restoredFrom)
+	}
+	if to == entities.TopicStatePublished || to == entities.TopicStateArchived {
+		tu.refreshContent(ctx)
+	}
+
+	return tu.topicRepository.GetTopicByKey(ctx, topicKey)
+}
+
+// loadForEditing returns the topic to change. A topic saved before the editorial workflow
+// has no state and is served as published; it gets a publication time so it stays served
+// once it has a state.
+func (tu *TopicUsecase) loadForEditing(ctx context.Context, topicKey string) (*entities.Topic, error) {
+	topic, err := tu.topicRepository.GetTopicByKey(ctx, topicKey)
+	if err != nil {
+		return nil, err
+	}
+	if topic == nil {
+		return nil, AppError.ErrTopicNotFound
+	}
+	if topic.State == "" && topic.PublishedAt == nil {
+		publishedAt := topic.UpdatedAt
+		topic.PublishedAt = &publishedAt
+	}
+	return topic, nil
+}
+
+// containsObjectID reports whether id is in ids
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SoftDeleteTopic takes a topic down and removes it from the admin lists. The deletion is
+// recorded as a transition to archived with who deleted it and when, so the topic's history
+// shows when it stopped being served.
 func (tu *TopicUsecase) SoftDeleteTopic(ctx context.Context, topicKey string) error {
 	deletedByUserID, err := extractUserID(ctx)
 	if err != nil {
 		return err
 	}
-	// verify existence
-	_, err = tu.topicRepository.GetTopicByKey(ctx, topicKey)
+	deletedByOID, err := primitive.ObjectIDFromHex(deletedByUserID)
+	if err != nil {
+		return AppError.ErrInvalidInput
+	}
+	existing, err := tu.loadForEditing(ctx, topicKey)
 	if err != nil {
 		return err
 	}
-	// repository should set status to deleted and record deletedBy if supported
+
+	now := time.Now()
+	existing.Transitions = append(existing.Transitions, entities.TopicTransition{
+		From: existing.EditorialState(), To: entities.TopicStateArchived, Actor: deletedByOID, At: now, Notes: topicDeletedNote,
+	})
+	existing.State = entities.TopicStateArchived
+	existing.PublishedAt = nil
+	existing.PublishedBy = primitive.NilObjectID
+	existing.UpdatedAt = now
+	existing.UpdatedBy = deletedByOID
+	if err := tu.topicRepository.UpdateTopic(ctx, topicKey, existing); err != nil {
+		return err
+	}
 	if err := tu.topicRepository.DeleteTopic(ctx, topicKey, deletedByUserID); err != nil {
 		return err
 	}
+	tu.refreshContent(ctx)
 	return nil
 }
+
+// refreshContent reloads the topics users are served; the periodic refresh retries on failure
+func (tu *TopicUsecase) refreshContent(ctx context.Context) {
+	if tu.content == nil {
+		return
+	}
+	if err := tu.content.RefreshTopics(ctx); err != nil {
+		log.Printf("❌ Failed to refresh topic content after topic change: %v", err)
+	}
+}
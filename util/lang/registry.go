@@ -0,0 +1,196 @@
+// Package lang is the registry of languages the service supports. Validation,
+// content lookup, prompts and user-facing messages all ask the registry instead
+// of hardcoding language codes, so a language is added through configuration.
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Language describes one supported language
+type Language struct {
+	Code       string `json:"code"`        // ISO 639-1 code, e.g. "am"
+	Name       string `json:"name"`        // English name, used in LLM prompts
+	NativeName string `json:"native_name"` // name in the language itself, for clients
+	Script     string `json:"script"`      // writing system, e.g. "Latin" or "Ethiopic"
+	// Fallback is the language whose text is used when a message is missing in this one
+	Fallback string `json:"fallback,omitempty"`
+	// Enabled languages are accepted by the API; disabled ones are configured but not yet served
+	Enabled bool `json:"enabled"`
+}
+
+// Registry holds the configured languages
+type Registry struct {
+	defaultCode string
+	order       []string
+	languages   map[string]Language
+}
+
+type registryFile struct {
+	Default   string     `json:"default"`
+	Languages []Language `json:"languages"`
+}
+
+// NewRegistry builds a registry; defaultCode must be an enabled language
+func NewRegistry(defaultCode string, languages []Language) (*Registry, error) {
+	r := &Registry{defaultCode: defaultCode, languages: make(map[string]Language, len(languages))}
+	for _, l := range languages {
+		l.Code = strings.ToLower(strings.TrimSpace(l.Code))
+		if l.Code == "" {
+			return nil, fmt.Errorf("language without a code")
+		}
+		if _, dup := r.languages[l.Code]; dup {
+			return nil, fmt.Errorf("language %q configured twice", l.Code)
+		}
+		if l.Name == "" {
+			l.Name = l.Code
+		}
+		r.languages[l.Code] = l
+		r.order = append(r.order, l.Code)
+	}
+	if d, ok := r.languages[defaultCode]; !ok || !d.Enabled {
+		return nil, fmt.Errorf("default language %q is not an enabled language", defaultCode)
+	}
+	for _, l := range r.languages {
+		if l.Fallback != "" {
+			if _, ok := r.languages[l.Fallback]; !ok {
+				return nil, fmt.Errorf("language %q falls back to unknown language %q", l.Code, l.Fallback)
+			}
+		}
+	}
+	return r, nil
+}
+
+// LoadRegistry reads a registry from a JSON file such as config/languages.json
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read language config: %w", err)
+	}
+	var file registryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse language config: %w", err)
+	}
+	return NewRegistry(file.Default, file.Languages)
+}
+
+// Get returns an enabled language
+func (r *Registry) Get(code string) (Language, bool) {
+	l, ok := r.languages[code]
+	if !ok || !l.Enabled {
+		return Language{}, false
+	}
+	return l, true
+}
+
+// IsSupported reports whether the API accepts the language code
+func (r *Registry) IsSupported(code string) bool {
+	_, ok := r.Get(code)
+	return ok
+}
+
+// Codes returns the enabled language codes in configuration order
+func (r *Registry) Codes() []string {
+	codes := make([]string, 0, len(r.order))
+	for _, code := range r.order {
+		if r.languages[code].Enabled {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// Languages returns the enabled languages in configuration order
+func (r *Registry) Languages() []Language {
+	out := make([]Language, 0, len(r.order))
+	for _, code := range r.Codes() {
+		out = append(out, r.languages[code])
+	}
+	return out
+}
+
+// DefaultCode is the language used when nothing better is available
+func (r *Registry) DefaultCode() string {
+	return r.defaultCode
+}
+
+// Name returns the English name of a language for prompts, or the code if unknown
+func (r *Registry) Name(code string) string {
+	if l, ok := r.languages[code]; ok {
+		return l.Name
+	}
+	return code
+}
+
+// Chain returns code followed by its fallback languages and finally the default language
+func (r *Registry) Chain(code string) []string {
+	var chain []string
+	seen := map[string]bool{}
+	for c := code; c != "" && !seen[c]; c = r.languages[c].Fallback {
+		seen[c] = true
+		chain = append(chain, c)
+	}
+	if !seen[r.defaultCode] {
+		chain = append(chain, r.defaultCode)
+	}
+	return chain
+}
+
+// Pick returns the text for code, following the fallback chain when it is missing
+func (r *Registry) Pick(code string, texts map[string]string) string {
+	for _, c := range r.Chain(code) {
+		if text, ok := texts[c]; ok && text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// builtin is used until a configuration is loaded, so tools and tests work without one
+var builtin = &Registry{
+	defaultCode: "en",
+	order:       []string{"en", "am"},
+	languages: map[string]Language{
+		"en": {Code: "en", Name: "English", NativeName: "English", Script: "Latin", Enabled: true},
+		"am": {Code: "am", Name: "Amharic", NativeName: "አማርኛ", Script: "Ethiopic", Fallback: "en", Enabled: true},
+	},
+}
+
+var current atomic.Pointer[Registry]
+
+// Default returns the process-wide registry
+func Default() *Registry {
+	if r := current.Load(); r != nil {
+		return r
+	}
+	return builtin
+}
+
+// SetDefault replaces the process-wide registry, normally once at startup
+func SetDefault(r *Registry) {
+	current.Store(r)
+}
+
+// IsSupported reports whether the process-wide registry accepts the language code
+func IsSupported(code string) bool {
+	return Default().IsSupported(code)
+}
+
+// Codes returns the enabled language codes of the process-wide registry
+func Codes() []string {
+	return Default().Codes()
+}
+
+// Name returns the English name of a language from the process-wide registry
+func Name(code string) string {
+	return Default().Name(code)
+}
+
+// Pick returns the text for code from the process-wide registry's fallback chain
+func Pick(code string, texts map[string]string) string {
+	return Default().Pick(code, texts)
+}
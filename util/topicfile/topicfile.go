@@ -0,0 +1,327 @@
+// Package topicfile reads and writes topic export files. JSON holds the records as the API
+// returns them; CSV has one row per topic and one column per field and language, named by the
+// field's JSON path (e.g. "translations.am.self_care"), for translators working in spreadsheets.
+//
+// In CSV cells, list items are one per line and an OTC category is written as
+// "category name: safety note".
+package topicfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"remedymate-backend/domain/AppError"
+	"remedymate-backend/domain/dto"
+	"remedymate-backend/domain/entities"
+	"remedymate-backend/util/lang"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// utf8BOM makes spreadsheet programs read the CSV as UTF-8, which Amharic text needs
+const utf8BOM = "\ufeff"
+
+// Columns before the per-language ones
+var fixedColumns = []string{"topic_key", "version", "state", "name_en", "name_am", "description_en", "description_am"}
+
+// Per-language columns, in order; each is repeated for every language
+var languageColumns = []string{"synonyms", "self_care", "otc_categories", "seek_care_if", "disclaimer"}
+
+// FormatOf returns the format named explicitly, or the one implied by a file name
+func FormatOf(name, fileName string) (string, error) {
+	if name == "" {
+		name = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), ".")
+	}
+	switch name {
+	case FormatJSON, FormatCSV:
+		return name, nil
+	case "":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("%w: unknown topic file format %q, use json or csv", AppError.ErrInvalidInput, name)
+}
+
+// ContentType returns the MIME type of a format
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// Write encodes the records in the given format
+func Write(w io.Writer, format string, records []dto.TopicRecord) error {
+	if format == FormatCSV {
+		return writeCSV(w, records)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// Read decodes records in the given format. Errors in the file's structure, such as an unknown
+// column, are returned with the row they were found in and wrap ErrInvalidInput.
+func Read(r io.Reader, format string) ([]dto.TopicRecord, error) {
+	if format == FormatCSV {
+		return readCSV(r)
+	}
+	var records []dto.TopicRecord
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&records); err != nil {
+		return nil, fmt.Errorf("%w: %v", AppError.ErrInvalidInput, err)
+	}
+	return records, nil
+}
+
+func writeCSV(w io.Writer, records []dto.TopicRecord) error {
+	codes := recordLanguages(records)
+	header := append([]string{}, fixedColumns...)
+	for _, column := range languageColumns {
+		for _, code := range codes {
+			header = append(header, languageColumn(column, code))
+		}
+	}
+
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	out := csv.NewWriter(w)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	for _, record := range records {
+		version := ""
+		if record.Version > 0 {
+			version = strconv.Itoa(record.Version)
+		}
+		row := []string{record.TopicKey, version, string(record.State), record.NameEN, record.NameAM, record.DescriptionEN, record.DescriptionAM}
+		for _, column := range languageColumns {
+			for _, code := range codes {
+				row = append(row, languageCell(record, column, code))
+			}
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func readCSV(r io.Reader) ([]dto.TopicRecord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	in := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	header, err := in.Read()
+	if err == io.EOF {
+		return []dto.TopicRecord{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %v", AppError.ErrInvalidInput, err)
+	}
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if !knownColumn(header[i]) {
+			return nil, fmt.Errorf("%w: unknown column %q", AppError.ErrInvalidInput, header[i])
+		}
+		if seen[header[i]] {
+			return nil, fmt.Errorf("%w: column %q appears twice", AppError.ErrInvalidInput, header[i])
+		}
+		seen[header[i]] = true
+	}
+	if !seen["topic_key"] {
+		return nil, fmt.Errorf("%w: the topic_key column is missing", AppError.ErrInvalidInput)
+	}
+
+	records := make([]dto.TopicRecord, 0)
+	for row := 1; ; row++ {
+		cells, err := in.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", AppError.ErrInvalidInput, row, err)
+		}
+		if strings.TrimSpace(strings.Join(cells, "")) == "" {
+			row--
+			continue
+		}
+		record := dto.TopicRecord{}
+		for i, column := range header {
+			if err := setColumn(&record, column, strings.TrimSpace(cells[i])); err != nil {
+				return nil, fmt.Errorf("%w: row %d: %v", AppError.ErrInvalidInput, row, err)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// languageColumn names a per-language column, e.g. "translations.am.self_care"
+func languageColumn(column, code string) string {
+	if column == "synonyms" {
+		return "synonyms." + code
+	}
+	return "translations." + code + "." + column
+}
+
+// splitColumn returns the field and language of a per-language column
+func splitColumn(name string) (column, code string, ok bool) {
+	parts := strings.Split(name, ".")
+	switch {
+	case len(parts) == 2 && parts[0] == "synonyms":
+		return "synonyms", parts[1], true
+	case len(parts) == 3 && parts[0] == "translations":
+		for _, column := range languageColumns[1:] {
+			if parts[2] == column {
+				return column, parts[1], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func knownColumn(name string) bool {
+	for _, column := range fixedColumns {
+		if name == column {
+			return true
+		}
+	}
+	_, _, ok := splitColumn(name)
+	return ok
+}
+
+func languageCell(record dto.TopicRecord, column, code string) string {
+	if column == "synonyms" {
+		return strings.Join(record.Synonyms[code], "\n")
+	}
+	content := record.Translations[code]
+	switch column {
+	case "self_care":
+		return strings.Join(content.SelfCare, "\n")
+	case "seek_care_if":
+		return strings.Join(content.SeekCareIf, "\n")
+	case "disclaimer":
+		return content.Disclaimer
+	}
+	lines := make([]string, 0, len(content.OTCCategories))
+	for _, otc := range content.OTCCategories {
+		line := otc.CategoryName
+		if otc.SafetyNote != "" {
+			line += ": " + otc.SafetyNote
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setColumn stores one cell in the record; empty cells are left unset
+func setColumn(record *dto.TopicRecord, name, cell string) error {
+	if cell == "" {
+		return nil
+	}
+	switch name {
+	case "topic_key":
+		record.TopicKey = cell
+	case "version":
+		version, err := strconv.Atoi(cell)
+		if err != nil {
+			return fmt.Errorf("version %q is not a number", cell)
+		}
+		record.Version = version
+	case "state":
+		// Exported for information only
+	case "name_en":
+		record.NameEN = cell
+	case "name_am":
+		record.NameAM = cell
+	case "description_en":
+		record.DescriptionEN = cell
+	case "description_am":
+		record.DescriptionAM = cell
+	default:
+		column, code, _ := splitColumn(name)
+		if column == "synonyms" {
+			if record.Synonyms == nil {
+				record.Synonyms = make(map[string][]string)
+			}
+			record.Synonyms[code] = cellLines(cell)
+			return nil
+		}
+		if record.Translations == nil {
+			record.Translations = make(map[string]entities.LocalizedGuidanceContent)
+		}
+		content := record.Translations[code]
+		switch column {
+		case "self_care":
+			content.SelfCare = cellLines(cell)
+		case "seek_care_if":
+			content.SeekCareIf = cellLines(cell)
+		case "disclaimer":
+			content.Disclaimer = cell
+		case "otc_categories":
+			for _, line := range cellLines(cell) {
+				name, note, _ := strings.Cut(line, ":")
+				content.OTCCategories = append(content.OTCCategories, entities.OTCCategory{
+					CategoryName: strings.TrimSpace(name),
+					SafetyNote:   strings.TrimSpace(note),
+				})
+			}
+		}
+		record.Translations[code] = content
+	}
+	return nil
+}
+
+// cellLines splits a cell into its non-empty lines
+func cellLines(cell string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(cell, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// recordLanguages lists the languages in the records: enabled languages in registry order,
+// then any others alphabetically
+func recordLanguages(records []dto.TopicRecord) []string {
+	present := make(map[string]bool)
+	for _, record := range records {
+		for code := range record.Synonyms {
+			present[code] = true
+		}
+		for code := range record.Translations {
+			present[code] = true
+		}
+	}
+	codes := make([]string, 0, len(present))
+	for _, code := range lang.Codes() {
+		if present[code] {
+			codes = append(codes, code)
+			delete(present, code)
+		}
+	}
+	others := make([]string, 0, len(present))
+	for code := range present {
+		others = append(others, code)
+	}
+	sort.Strings(others)
+	return append(codes, others...)
+}
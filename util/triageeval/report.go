@@ -0,0 +1,187 @@
+// Package triageeval scores triage classifications against a labeled dataset: the confusion
+// matrix, RED recall overall and per rule, and the cases that were under-triaged.
+package triageeval
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"remedymate-backend/domain/entities"
+)
+
+// EvalCase is one labeled symptom text from the golden dataset
+type EvalCase struct {
+	ID       string               `json:"id"`
+	Text     string               `json:"text"`
+	Language string               `json:"language"`
+	Expected entities.TriageLevel `json:"expected"`
+	// Patient is optional context for cases that exercise conditional rules
+	Patient *entities.PatientContext `json:"patient,omitempty"`
+	// Rule names the RED flag rule the case is meant to trigger, used for per-rule recall
+	Rule string `json:"rule,omitempty"`
+}
+
+// CaseResult is the outcome of classifying one case
+type CaseResult struct {
+	Case   EvalCase              `json:"case"`
+	Got    entities.TriageLevel  `json:"got,omitempty"`
+	Flags  []string              `json:"flags,omitempty"`
+	Source entities.TriageSource `json:"source,omitempty"`
+	Error  string                `json:"error,omitempty"`
+
+	Agreement float64 `json:"agreement,omitempty"`
+	LLMCalls  int     `json:"llmCalls"`
+}
+
+// RuleRecall is the RED recall for the cases labeled with one rule
+type RuleRecall struct {
+	Rule   string  `json:"rule"`
+	Total  int     `json:"total"`
+	Hits   int     `json:"hits"`
+	Recall float64 `json:"recall"`
+}
+
+// Report summarises an evaluation run
+type Report struct {
+	LLM          string `json:"llm"`
+	RulesVersion string `json:"rulesVersion,omitempty"`
+	Total        int    `json:"total"`
+	Correct      int    `json:"correct"`
+	Errors       int    `json:"errors"`
+	Samples      int    `json:"samples"`
+	LLMCalls     int    `json:"llmCalls"`
+	// Disagreements counts cases where consensus samples did not all agree
+	Disagreements int `json:"disagreements"`
+	// Confusion maps expected level -> predicted level (or "ERROR") -> count
+	Confusion    map[string]map[string]int `json:"confusion"`
+	RedRecall    float64                   `json:"redRecall"`
+	RuleRecall   []RuleRecall              `json:"ruleRecall"`
+	UnderTriaged []CaseResult              `json:"underTriaged"`
+	Results      []CaseResult              `json:"results"`
+}
+
+const errorColumn = "ERROR"
+
+var reportLevels = []string{
+	string(entities.TriageLevelRed),
+	string(entities.TriageLevelYellow),
+	string(entities.TriageLevelGreen),
+}
+
+// BuildReport computes the confusion matrix, RED recall and under-triaged cases
+func BuildReport(results []CaseResult) Report {
+	report := Report{
+		Total:        len(results),
+		Confusion:    make(map[string]map[string]int),
+		UnderTriaged: []CaseResult{},
+		Results:      results,
+	}
+	for _, level := range reportLevels {
+		report.Confusion[level] = make(map[string]int)
+	}
+
+	redTotal, redHits := 0, 0
+	perRule := make(map[string]*RuleRecall)
+	for _, r := range results {
+		expected := string(r.Case.Expected)
+		predicted := string(r.Got)
+		if r.Error != "" {
+			predicted = errorColumn
+			report.Errors++
+		}
+		report.Confusion[expected][predicted]++
+		report.LLMCalls += r.LLMCalls
+		if r.Agreement > 0 && r.Agreement < 1 {
+			report.Disagreements++
+		}
+		if predicted == expected {
+			report.Correct++
+		}
+
+		// An error gives the user no triage at all, so it counts as under-triage
+		if r.Error != "" || r.Got.Severity() < r.Case.Expected.Severity() {
+			report.UnderTriaged = append(report.UnderTriaged, r)
+		}
+
+		if r.Case.Expected != entities.TriageLevelRed {
+			continue
+		}
+		hit := r.Error == "" && r.Got == entities.TriageLevelRed
+		redTotal++
+		if hit {
+			redHits++
+		}
+		rule := r.Case.Rule
+		if rule == "" {
+			rule = "(unlabeled)"
+		}
+		rr, ok := perRule[rule]
+		if !ok {
+			rr = &RuleRecall{Rule: rule}
+			perRule[rule] = rr
+		}
+		rr.Total++
+		if hit {
+			rr.Hits++
+		}
+	}
+
+	if redTotal > 0 {
+		report.RedRecall = float64(redHits) / float64(redTotal)
+	}
+	for _, rr := range perRule {
+		rr.Recall = float64(rr.Hits) / float64(rr.Total)
+		report.RuleRecall = append(report.RuleRecall, *rr)
+	}
+	// Worst rules first so regressions are at the top
+	sort.Slice(report.RuleRecall, func(i, j int) bool {
+		if report.RuleRecall[i].Recall != report.RuleRecall[j].Recall {
+			return report.RuleRecall[i].Recall < report.RuleRecall[j].Recall
+		}
+		return report.RuleRecall[i].Rule < report.RuleRecall[j].Rule
+	})
+
+	return report
+}
+
+// PrintReport writes a human readable version of the report
+func PrintReport(w io.Writer, report Report) {
+	fmt.Fprintf(w, "Triage evaluation (llm=%s, rules=%s, samples=%d)\n", report.LLM, report.RulesVersion, report.Samples)
+	accuracy := 0.0
+	if report.Total > 0 {
+		accuracy = float64(report.Correct) / float64(report.Total)
+	}
+	fmt.Fprintf(w, "Cases: %d  Correct: %d (%.1f%%)  Errors: %d\n", report.Total, report.Correct, accuracy*100, report.Errors)
+	fmt.Fprintf(w, "LLM calls: %d  Sample disagreements: %d\n\n", report.LLMCalls, report.Disagreements)
+
+	columns := append(append([]string{}, reportLevels...), errorColumn)
+	fmt.Fprintln(w, "Confusion matrix (rows = expected, columns = predicted)")
+	fmt.Fprintf(w, "%-10s", "")
+	for _, col := range columns {
+		fmt.Fprintf(w, "%8s", col)
+	}
+	fmt.Fprintln(w)
+	for _, row := range reportLevels {
+		fmt.Fprintf(w, "%-10s", row)
+		for _, col := range columns {
+			fmt.Fprintf(w, "%8d", report.Confusion[row][col])
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\nRED recall: %.3f\n", report.RedRecall)
+	for _, rr := range report.RuleRecall {
+		fmt.Fprintf(w, "  %5.3f  %d/%d  %s\n", rr.Recall, rr.Hits, rr.Total, rr.Rule)
+	}
+
+	fmt.Fprintf(w, "\nUnder-triaged cases: %d\n", len(report.UnderTriaged))
+	for _, r := range report.UnderTriaged {
+		got := string(r.Got)
+		if r.Error != "" {
+			got = errorColumn + ": " + r.Error
+		}
+		fmt.Fprintf(w, "  [%s] expected %s, got %s  %q\n", r.Case.ID, r.Case.Expected, got, strings.TrimSpace(r.Case.Text))
+	}
+}
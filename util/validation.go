@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	derrors "remedymate-backend/domain/AppError"
+	languages "remedymate-backend/util/lang"
 )
 
 // ValidateLanguage checks supported language codes.
@@ -11,7 +12,7 @@ func ValidateLanguage(lang string) error {
 	if lang == "" {
 		return fmt.Errorf("language is required")
 	}
-	if lang != "en" && lang != "am" {
+	if !languages.IsSupported(lang) {
 		return derrors.ErrUnsupportedLanguage
 	}
 	return nil